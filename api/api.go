@@ -56,7 +56,7 @@ import (
 )
 
 // StartApi starts the API server of the canary
-func StartApi(data data.Database, metrics metric.Metrics, config *Configuration, log *zap.SugaredLogger) error {
+func StartApi(data data.Store, metrics metric.Metrics, config *Configuration, log *zap.SugaredLogger) error {
 	a := &Api{
 		data:    data,
 		metrics: metrics,
@@ -23,6 +23,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/telekom/canary-bot/data"
@@ -36,7 +38,7 @@ import (
 
 // Api implements the protobuf interface
 type Api struct {
-	data    data.Database
+	data    data.Store
 	metrics metric.Metrics
 	config  *Configuration
 	log     *zap.SugaredLogger
@@ -44,6 +46,7 @@ type Api struct {
 
 type Configuration struct {
 	NodeName       string
+	NodeLabels     map[string]string
 	Address        string
 	Port           int64
 	Tokens         []string
@@ -62,11 +65,14 @@ func (a *Api) ListSamples(ctx context.Context, req *connect.Request[apiv1.ListSa
 
 	for _, sample := range a.data.GetSampleList() {
 		samples = append(samples, &apiv1.Sample{
-			From:  sample.From,
-			To:    sample.To,
-			Type:  data.SampleName[sample.Key],
-			Value: sample.Value,
-			Ts:    time.Unix(sample.Ts, 0).String(),
+			From:         sample.From,
+			To:           sample.To,
+			Type:         data.SampleName[sample.Key],
+			Value:        strconv.FormatFloat(sample.Value, 'f', -1, 64),
+			Ts:           time.Unix(sample.Ts, 0).String(),
+			NumericValue: sample.Value,
+			Status:       apiv1.SampleStatus(sample.Status),
+			Unit:         sample.Unit,
 		})
 	}
 
@@ -75,15 +81,103 @@ func (a *Api) ListSamples(ctx context.Context, req *connect.Request[apiv1.ListSa
 	}), nil
 }
 
+// ListSampleHistory lists the local history of the sample series matching the request
+func (a *Api) ListSampleHistory(ctx context.Context, req *connect.Request[apiv1.ListSampleHistoryRequest]) (*connect.Response[apiv1.ListSampleHistoryResponse], error) {
+	histories := []*apiv1.SampleHistory{}
+
+	for _, sample := range a.data.GetSampleList() {
+		if !matchSample(sample, req.Msg.From, req.Msg.To, req.Msg.Type) {
+			continue
+		}
+
+		entries := []*apiv1.HistoryEntry{}
+		for _, entry := range a.data.GetSampleHistory(sample.Id) {
+			entries = append(entries, &apiv1.HistoryEntry{
+				Value:  entry.Value,
+				Status: apiv1.SampleStatus(entry.Status),
+				Ts:     time.Unix(entry.Ts, 0).String(),
+			})
+		}
+
+		histories = append(histories, &apiv1.SampleHistory{
+			From:    sample.From,
+			To:      sample.To,
+			Type:    data.SampleName[sample.Key],
+			Unit:    sample.Unit,
+			Entries: entries,
+		})
+	}
+
+	return connect.NewResponse(&apiv1.ListSampleHistoryResponse{
+		Histories: histories,
+	}), nil
+}
+
+// GetSampleStats summarises the local history of the sample series matching the request
+func (a *Api) GetSampleStats(ctx context.Context, req *connect.Request[apiv1.GetSampleStatsRequest]) (*connect.Response[apiv1.GetSampleStatsResponse], error) {
+	var since int64
+	if req.Msg.Window != "" {
+		window, err := time.ParseDuration(req.Msg.Window)
+		if err != nil || window <= 0 {
+			return nil, connect.NewError(
+				connect.CodeInvalidArgument,
+				fmt.Errorf("invalid window: %s", req.Msg.Window),
+			)
+		}
+		since = time.Now().Add(-window).Unix()
+	}
+
+	stats := []*apiv1.SampleStats{}
+	for _, sample := range a.data.GetSampleList() {
+		if !matchSample(sample, req.Msg.From, req.Msg.To, req.Msg.Type) {
+			continue
+		}
+
+		s := data.GetSampleStats(a.data.GetSampleHistory(sample.Id), since)
+		stats = append(stats, &apiv1.SampleStats{
+			From:      sample.From,
+			To:        sample.To,
+			Type:      data.SampleName[sample.Key],
+			Unit:      sample.Unit,
+			Count:     int64(s.Count),
+			Min:       s.Min,
+			Max:       s.Max,
+			Mean:      s.Mean,
+			P50:       s.P50,
+			P90:       s.P90,
+			P99:       s.P99,
+			LossRatio: s.LossRatio,
+		})
+	}
+
+	return connect.NewResponse(&apiv1.GetSampleStatsResponse{
+		Stats: stats,
+	}), nil
+}
+
+// matchSample checks if the sample matches the filters, empty filters match every sample
+func matchSample(sample *data.Sample, from string, to string, sampleType string) bool {
+	return (from == "" || from == sample.From) &&
+		(to == "" || to == sample.To) &&
+		(sampleType == "" || sampleType == data.SampleName[sample.Key])
+}
+
 // ListNodes lists all known nodes in mesh
 func (a *Api) ListNodes(ctx context.Context, req *connect.Request[apiv1.ListNodesRequest]) (*connect.Response[apiv1.ListNodesResponse], error) {
 	nodes := []string{a.config.NodeName}
+	nodeDetails := []*apiv1.Node{{Name: a.config.NodeName, Labels: a.config.NodeLabels}}
 
 	for _, node := range a.data.GetNodeList() {
+		// dead and left nodes are kept to not re-add them, but are not in the mesh
+		if node.State == data.NodeStateDead || node.State == data.NodeStateLeft {
+			continue
+		}
 		nodes = append(nodes, node.Name)
+		nodeDetails = append(nodeDetails, &apiv1.Node{Name: node.Name, Labels: node.Labels})
 	}
 
 	return connect.NewResponse(&apiv1.ListNodesResponse{
-		Nodes: nodes,
+		Nodes:       nodes,
+		NodeDetails: nodeDetails,
 	}), nil
 }
@@ -0,0 +1,153 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+	apiv1 "github.com/telekom/canary-bot/proto/api/v1"
+)
+
+func Test_GetSampleStats(t *testing.T) {
+	now := time.Now().Unix()
+	old := time.Now().Add(-2 * time.Hour).Unix()
+
+	// owl->swan: 10 measurements and 2 failures within the last hour, one outlier before
+	rtt := []data.HistoryEntry{{Value: 100, Status: data.SampleStatusOk, Ts: old}}
+	for i := 1; i <= 10; i++ {
+		rtt = append(rtt, data.HistoryEntry{Value: float64(i), Status: data.SampleStatusOk, Ts: now})
+	}
+	rtt = append(rtt,
+		data.HistoryEntry{Value: math.NaN(), Status: data.SampleStatusError, Ts: now},
+		data.HistoryEntry{Value: math.NaN(), Status: data.SampleStatusTimeout, Ts: now},
+	)
+	histories := map[uint32][]data.HistoryEntry{
+		1: rtt,
+		2: {{Value: 4, Status: data.SampleStatusOk, Ts: now}, {Value: 2, Status: data.SampleStatusOk, Ts: now}},
+		3: {{Value: 3, Status: data.SampleStatusOk, Ts: now}},
+	}
+	store := &data.StoreMock{
+		GetSampleListFunc: func() []*data.Sample {
+			return []*data.Sample{
+				{Id: 1, From: "owl", To: "swan", Key: data.RttTotal, Unit: "ns"},
+				{Id: 2, From: "owl", To: "eagle", Key: data.RttTotal, Unit: "ns"},
+				{Id: 3, From: "swan", To: "owl", Key: data.RttRequest, Unit: "ns"},
+			}
+		},
+		GetSampleHistoryFunc: func(id uint32) []data.HistoryEntry {
+			return histories[id]
+		},
+	}
+	a := &Api{data: store}
+
+	type stats struct {
+		Count                    int64
+		Min, Max, Mean           float64
+		P50, P90, P99, LossRatio float64
+	}
+	owlSwan := stats{Count: 12, Min: 1, Max: 10, Mean: 5.5, P50: 5, P90: 9, P99: 10, LossRatio: 2.0 / 12}
+
+	tests := []struct {
+		name     string
+		req      *apiv1.GetSampleStatsRequest
+		expected map[string]stats
+		code     connect.Code
+	}{
+		{
+			name: "Invalid window",
+			req:  &apiv1.GetSampleStatsRequest{Window: "an hour"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "Negative window",
+			req:  &apiv1.GetSampleStatsRequest{Window: "-5m"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name:     "Filtered series within the window",
+			req:      &apiv1.GetSampleStatsRequest{From: "owl", To: "swan", Type: "rtt_total", Window: "1h"},
+			expected: map[string]stats{"owl->swan rtt_total": owlSwan},
+		},
+		{
+			name: "Whole history",
+			req:  &apiv1.GetSampleStatsRequest{From: "owl", To: "swan"},
+			expected: map[string]stats{
+				"owl->swan rtt_total": {Count: 13, Min: 1, Max: 100, Mean: 155.0 / 11, P50: 6, P90: 10, P99: 100, LossRatio: 2.0 / 13},
+			},
+		},
+		{
+			name: "Filtered by type",
+			req:  &apiv1.GetSampleStatsRequest{Type: "rtt_total", Window: "1h"},
+			expected: map[string]stats{
+				"owl->swan rtt_total":  owlSwan,
+				"owl->eagle rtt_total": {Count: 2, Min: 2, Max: 4, Mean: 3, P50: 2, P90: 4, P99: 4},
+			},
+		},
+		{
+			name: "Filtered by receiver",
+			req:  &apiv1.GetSampleStatsRequest{To: "owl"},
+			expected: map[string]stats{
+				"swan->owl rtt_request": {Count: 1, Min: 3, Max: 3, Mean: 3, P50: 3, P90: 3, P99: 3},
+			},
+		},
+		{
+			name:     "No matching series",
+			req:      &apiv1.GetSampleStatsRequest{From: "crow"},
+			expected: map[string]stats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := a.GetSampleStats(context.Background(), connect.NewRequest(tt.req))
+			if tt.code != 0 {
+				if connect.CodeOf(err) != tt.code {
+					t.Fatalf("the error (%v) is not as expected: %v", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("get sample stats failed: %v", err)
+			}
+
+			result := map[string]stats{}
+			for _, s := range res.Msg.Stats {
+				if s.Unit != "ns" {
+					t.Errorf("the unit (%v) is not as expected: ns", s.Unit)
+				}
+				result[s.From+"->"+s.To+" "+s.Type] = stats{
+					Count: s.Count, Min: s.Min, Max: s.Max, Mean: s.Mean,
+					P50: s.P50, P90: s.P90, P99: s.P99, LossRatio: s.LossRatio,
+				}
+			}
+			if diff := deep.Equal(result, tt.expected); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
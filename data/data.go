@@ -22,8 +22,11 @@
 package data
 
 import (
+	"errors"
 	l "log"
+	"math"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	h "github.com/telekom/canary-bot/helper"
@@ -38,13 +41,173 @@ const (
 	State      = 1
 	RttTotal   = 2
 	RttRequest = 3
+	RttDns     = 7
+	RttConnect = 8
+	RttTls     = 9
+
+	HttpStatus    = 4
+	HttpLatency   = 5
+	HttpBodyMatch = 6
+
+	LossPercent = 10
+	Jitter      = 11
+	RttMin      = 12
+	RttAvg      = 13
+	RttMax      = 14
+
+	UdpLossPercent = 15
+	UdpJitter      = 16
+	UdpRttMin      = 17
+	UdpRttAvg      = 18
+	UdpRttMax      = 19
+
+	TcpConnect = 20
+	TcpSuccess = 21
+
+	DnsDuration = 22
+	DnsAnswers  = 23
+	DnsMatch    = 24
+
+	TlsHandshake  = 25
+	TlsExpiryDays = 26
+	TlsChainValid = 27
+	TlsVersion    = 28
+
+	ClockOffset       = 29
+	ClockSkewExceeded = 30
+
+	ThroughputUp   = 31
+	ThroughputDown = 32
+
+	GrpcHealthStatus  = 33
+	GrpcHealthLatency = 34
+
+	ExecExitCode = 35
+	ExecDuration = 36
+	ExecValue    = 37
 )
 
 // SampleName holds the mapping of the sample keys
 var SampleName = map[int64]string{
-	State:      "state",
-	RttTotal:   "rtt_total",
-	RttRequest: "rtt_request",
+	State:         "state",
+	RttTotal:      "rtt_total",
+	RttRequest:    "rtt_request",
+	RttDns:        "rtt_dns",
+	RttConnect:    "rtt_connect",
+	RttTls:        "rtt_tls",
+	HttpStatus:    "http_status",
+	HttpLatency:   "http_latency",
+	HttpBodyMatch: "http_body_match",
+
+	LossPercent:    "loss_percent",
+	Jitter:         "jitter",
+	RttMin:         "rtt_min",
+	RttAvg:         "rtt_avg",
+	RttMax:         "rtt_max",
+	UdpLossPercent: "udp_loss_percent",
+	UdpJitter:      "udp_jitter",
+	UdpRttMin:      "udp_rtt_min",
+	UdpRttAvg:      "udp_rtt_avg",
+	UdpRttMax:      "udp_rtt_max",
+	TcpConnect:     "tcp_connect",
+	TcpSuccess:     "tcp_success",
+	DnsDuration:    "dns_duration",
+	DnsAnswers:     "dns_answers",
+	DnsMatch:       "dns_match",
+	TlsHandshake:   "tls_handshake",
+	TlsExpiryDays:  "tls_expiry_days",
+	TlsChainValid:  "tls_chain_valid",
+	TlsVersion:     "tls_version",
+
+	ClockOffset:       "clock_offset",
+	ClockSkewExceeded: "clock_skew_exceeded",
+	ThroughputUp:      "throughput_up",
+	ThroughputDown:    "throughput_down",
+	GrpcHealthStatus:  "grpc_health_status",
+	GrpcHealthLatency: "grpc_health_latency",
+	ExecExitCode:      "exec_exit_code",
+	ExecDuration:      "exec_duration",
+	ExecValue:         "exec_value",
+}
+
+// Node states of the mesh
+const (
+	NodeStateOk      = 1
+	NodeStateTimeout = 2
+	NodeStateDead    = 3
+	NodeStateLeft    = 4
+)
+
+// Sample status, equal to meshv1.SampleStatus
+const (
+	SampleStatusOk      = 1
+	SampleStatusTimeout = 2
+	SampleStatusError   = 3
+)
+
+// Sample units
+const (
+	UnitNanoseconds    = "ns"
+	UnitPercent        = "percent"
+	UnitBool           = "bool"
+	UnitCount          = "count"
+	UnitDays           = "days"
+	UnitBytesPerSecond = "bytes_per_second"
+)
+
+// SampleUnit holds the unit of the sample keys
+var SampleUnit = map[int64]string{
+	RttTotal:          UnitNanoseconds,
+	RttRequest:        UnitNanoseconds,
+	RttDns:            UnitNanoseconds,
+	RttConnect:        UnitNanoseconds,
+	RttTls:            UnitNanoseconds,
+	HttpStatus:        "status_code",
+	HttpLatency:       UnitNanoseconds,
+	HttpBodyMatch:     UnitBool,
+	LossPercent:       UnitPercent,
+	Jitter:            UnitNanoseconds,
+	RttMin:            UnitNanoseconds,
+	RttAvg:            UnitNanoseconds,
+	RttMax:            UnitNanoseconds,
+	UdpLossPercent:    UnitPercent,
+	UdpJitter:         UnitNanoseconds,
+	UdpRttMin:         UnitNanoseconds,
+	UdpRttAvg:         UnitNanoseconds,
+	UdpRttMax:         UnitNanoseconds,
+	TcpConnect:        UnitNanoseconds,
+	TcpSuccess:        UnitBool,
+	DnsDuration:       UnitNanoseconds,
+	DnsAnswers:        UnitCount,
+	DnsMatch:          UnitBool,
+	TlsHandshake:      UnitNanoseconds,
+	TlsExpiryDays:     UnitDays,
+	TlsChainValid:     UnitBool,
+	TlsVersion:        "version",
+	ClockOffset:       UnitNanoseconds,
+	ClockSkewExceeded: UnitBool,
+	ThroughputUp:      UnitBytesPerSecond,
+	ThroughputDown:    UnitBytesPerSecond,
+	GrpcHealthStatus:  "grpc_health_status",
+	GrpcHealthLatency: UnitNanoseconds,
+	ExecExitCode:      "exit_code",
+	ExecDuration:      UnitNanoseconds,
+}
+
+// RegisterSampleName adds the name of a sample key e.g. of a custom probe.
+// Register all sample names before the mesh is created.
+func RegisterSampleName(key int64, name string) error {
+	if existing, exists := SampleName[key]; exists && existing != name {
+		return errors.New("sample key " + strconv.FormatInt(key, 10) + " already registered as " + existing)
+	}
+	SampleName[key] = name
+	return nil
+}
+
+// RegisterSampleUnit sets the unit of a sample key e.g. of a custom probe.
+// Register all sample units before the mesh is created.
+func RegisterSampleUnit(key int64, unit string) {
+	SampleUnit[key] = unit
 }
 
 // Database that is used by the mesh.
@@ -54,6 +217,10 @@ var SampleName = map[int64]string{
 type Database struct {
 	*memdb.MemDB
 	log *zap.SugaredLogger
+	// seq is the sequence number of the last sample change
+	seq *atomic.Uint64
+	// history holds former values per sample series
+	history *history
 }
 
 // Node represents a member of the canary mesh.
@@ -68,6 +235,10 @@ type Node struct {
 	State int
 	// StateChangeTs is the timestamp when the state changed last time
 	StateChangeTs int64
+	// Incarnation is increased by the node itself to refute a suspicion
+	Incarnation uint64
+	// Labels of the node e.g. zone, region, cluster
+	Labels map[string]string
 }
 
 // Sample represents a measurement of the canary mesh.
@@ -77,14 +248,20 @@ type Sample struct {
 	To   string
 	// Key is the sample name
 	Key int64
-	// Value is the measurement value
-	Value string
-	Ts    int64
+	// Value is the measurement value, NaN if the measurement failed
+	Value float64
+	// Status of the measurement e.g. SampleStatusOk
+	Status int
+	// Unit of the value e.g. ns
+	Unit string
+	Ts   int64
+	// Seq is the local sequence number of the last change
+	Seq uint64
 }
 
 // NewMemDB Will create an in-memory database and a logger.
 // The database will be created with 2 schemas: node, sample
-func NewMemDB(logger *zap.SugaredLogger) (Database, error) {
+func NewMemDB(logger *zap.SugaredLogger) (*Database, error) {
 	defer logger.Sync()
 
 	// 2 tables: node, sample
@@ -148,32 +325,34 @@ func NewMemDB(logger *zap.SugaredLogger) (Database, error) {
 						AllowMissing: false,
 						Indexer:      &memdb.IntFieldIndex{Field: "Key"},
 					},
-					"value": {
-						Name:         "value",
-						Unique:       false,
-						AllowMissing: false,
-						Indexer:      &memdb.StringFieldIndex{Field: "Value"},
-					},
 					"ts": {
 						Name:         "ts",
 						Unique:       false,
 						AllowMissing: false,
 						Indexer:      &memdb.IntFieldIndex{Field: "Ts"},
 					},
+					"seq": {
+						Name:         "seq",
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &memdb.UintFieldIndex{Field: "Seq"},
+					},
 				},
 			},
 		},
 	}
 	// Create new database
 	db, err := memdb.NewMemDB(schema)
-	return Database{db, logger}, err
+	return &Database{db, logger, &atomic.Uint64{}, newHistory()}, err
 }
 
 // Convert a given database node to a mesh node
 func (n *Node) Convert() *meshv1.Node {
 	return &meshv1.Node{
-		Name:   n.Name,
-		Target: n.Target,
+		Name:        n.Name,
+		Target:      n.Target,
+		Incarnation: n.Incarnation,
+		Labels:      n.Labels,
 	}
 }
 
@@ -191,7 +370,51 @@ func Convert(n *meshv1.Node, state int) *Node {
 		Target:        n.Target,
 		State:         state,
 		StateChangeTs: time.Now().Unix(),
+		Incarnation:   n.Incarnation,
+		Labels:        n.Labels,
+	}
+}
+
+// Convert a database sample to a mesh sample.
+// The value is set as string as well for nodes without numeric values.
+func (s *Sample) Convert() *meshv1.Sample {
+	return &meshv1.Sample{
+		From:         s.From,
+		To:           s.To,
+		Key:          s.Key,
+		Value:        strconv.FormatFloat(s.Value, 'f', -1, 64),
+		Ts:           s.Ts,
+		NumericValue: s.Value,
+		Status:       meshv1.SampleStatus(s.Status),
+		Unit:         s.Unit,
+	}
+}
+
+// ConvertSample converts a mesh sample to a database sample.
+// Samples of nodes without numeric values are decoded from the string value.
+func ConvertSample(s *meshv1.Sample) *Sample {
+	sample := &Sample{
+		From:   s.From,
+		To:     s.To,
+		Key:    s.Key,
+		Value:  s.NumericValue,
+		Status: int(s.Status),
+		Unit:   s.Unit,
+		Ts:     s.Ts,
+	}
+
+	if s.Status == meshv1.SampleStatus_SAMPLE_STATUS_UNSPECIFIED {
+		sample.Unit = SampleUnit[s.Key]
+		value, err := strconv.ParseFloat(s.Value, 64)
+		if err != nil || math.IsNaN(value) {
+			sample.Value = math.NaN()
+			sample.Status = SampleStatusError
+		} else {
+			sample.Value = value
+			sample.Status = SampleStatusOk
+		}
 	}
+	return sample
 }
 
 // GetId returns the id of a database node.
@@ -0,0 +1,142 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"sync"
+	"time"
+)
+
+// Default limits of the sample history per series, the size covers the time window at a 3s interval
+const (
+	DefaultHistorySize   = 1200
+	DefaultHistoryMaxAge = time.Hour
+)
+
+// HistoryEntry is a former value of a sample series
+type HistoryEntry struct {
+	Value  float64
+	Status int
+	Ts     int64
+}
+
+// history holds a bounded ring buffer of entries per sample ID
+type history struct {
+	mu     sync.Mutex
+	size   int
+	maxAge time.Duration
+	series map[uint32]*ring
+}
+
+// ring is a fixed size buffer overwriting the oldest entry
+type ring struct {
+	entries []HistoryEntry
+	start   int
+	len     int
+}
+
+func newHistory() *history {
+	return &history{
+		size:   DefaultHistorySize,
+		maxAge: DefaultHistoryMaxAge,
+		series: map[uint32]*ring{},
+	}
+}
+
+func (r *ring) add(entry HistoryEntry) {
+	if r.len < len(r.entries) {
+		r.entries[(r.start+r.len)%len(r.entries)] = entry
+		r.len++
+		return
+	}
+	r.entries[r.start] = entry
+	r.start = (r.start + 1) % len(r.entries)
+}
+
+// list returns the entries from oldest to newest
+func (r *ring) list() []HistoryEntry {
+	entries := make([]HistoryEntry, 0, r.len)
+	for i := 0; i < r.len; i++ {
+		entries = append(entries, r.entries[(r.start+i)%len(r.entries)])
+	}
+	return entries
+}
+
+// SetHistoryLimit limits the sample history per series to the amount of entries
+// and the time window. A size of 0 disables the history, a maxAge of 0 disables the time window.
+// Existing history is dropped.
+func (db *Database) SetHistoryLimit(size int, maxAge time.Duration) {
+	db.history.mu.Lock()
+	defer db.history.mu.Unlock()
+
+	db.history.size = size
+	db.history.maxAge = maxAge
+	db.history.series = map[uint32]*ring{}
+}
+
+// addHistory appends the sample to the history of its series
+func (db *Database) addHistory(sample *Sample) {
+	db.history.mu.Lock()
+	defer db.history.mu.Unlock()
+
+	if db.history.size <= 0 {
+		return
+	}
+	series, exists := db.history.series[sample.Id]
+	if !exists {
+		series = &ring{entries: make([]HistoryEntry, db.history.size)}
+		db.history.series[sample.Id] = series
+	}
+	series.add(HistoryEntry{Value: sample.Value, Status: sample.Status, Ts: sample.Ts})
+}
+
+// deleteHistory removes the history of a series
+func (db *Database) deleteHistory(id uint32) {
+	db.history.mu.Lock()
+	defer db.history.mu.Unlock()
+
+	delete(db.history.series, id)
+}
+
+// GetSampleHistory returns the history of a sample series from oldest to newest
+// within the time window of the history
+func (db *Database) GetSampleHistory(id uint32) []HistoryEntry {
+	db.history.mu.Lock()
+	defer db.history.mu.Unlock()
+
+	series, exists := db.history.series[id]
+	if !exists {
+		return []HistoryEntry{}
+	}
+	entries := series.list()
+	if db.history.maxAge <= 0 {
+		return entries
+	}
+
+	oldest := time.Now().Add(-db.history.maxAge).Unix()
+	for i, entry := range entries {
+		if entry.Ts >= oldest {
+			return entries[i:]
+		}
+	}
+	return []HistoryEntry{}
+}
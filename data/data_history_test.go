@@ -0,0 +1,96 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func Test_GetSampleHistory(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name     string
+		size     int
+		maxAge   time.Duration
+		ts       []int64
+		expected []int64
+	}{
+		{
+			name:     "Below size",
+			size:     3,
+			ts:       []int64{now - 2, now - 1},
+			expected: []int64{now - 2, now - 1},
+		},
+		{
+			name:     "Oldest entries overwritten",
+			size:     3,
+			ts:       []int64{now - 5, now - 4, now - 3, now - 2, now - 1},
+			expected: []int64{now - 3, now - 2, now - 1},
+		},
+		{
+			name:     "Entries outside the time window",
+			size:     5,
+			maxAge:   time.Minute,
+			ts:       []int64{now - 300, now - 120, now - 30, now},
+			expected: []int64{now - 30, now},
+		},
+		{
+			name:     "History disabled",
+			size:     0,
+			ts:       []int64{now - 1, now},
+			expected: []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := NewMemDB(log)
+			db.SetHistoryLimit(tt.size, tt.maxAge)
+			for i, ts := range tt.ts {
+				db.SetSample(&Sample{From: "owl", To: "swan", Key: RttTotal, Value: float64(i), Ts: ts})
+			}
+
+			result := []int64{}
+			for _, entry := range db.GetSampleHistory(GetSampleId(&Sample{From: "owl", To: "swan", Key: RttTotal})) {
+				result = append(result, entry.Ts)
+			}
+			if diff := deep.Equal(result, tt.expected); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_DeleteSampleHistory(t *testing.T) {
+	db, _ := NewMemDB(log)
+	sample := &Sample{From: "owl", To: "swan", Key: RttTotal, Value: 1, Ts: time.Now().Unix()}
+	db.SetSample(sample)
+	db.DeleteSample(sample.Id)
+
+	if history := db.GetSampleHistory(sample.Id); len(history) != 0 {
+		t.Errorf("the history (%v) of the deleted sample is not empty", history)
+	}
+}
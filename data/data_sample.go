@@ -21,7 +21,10 @@
 
 package data
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // SetSample inserts a measurement sample in the db
 func (db *Database) SetSample(sample *Sample) {
@@ -30,6 +33,8 @@ func (db *Database) SetSample(sample *Sample) {
 	defer txn.Abort()
 
 	sample.Id = GetSampleId(sample)
+	// write transactions are serialized, the sequence will be committed in order
+	sample.Seq = db.seq.Add(1)
 	err := txn.Insert("sample", sample)
 	if err != nil {
 		panic(err)
@@ -37,9 +42,10 @@ func (db *Database) SetSample(sample *Sample) {
 
 	// Commit the transaction
 	txn.Commit()
+	db.addHistory(sample)
 }
 
-// SetSampleNaN sets a sample to not a number "NaN"
+// SetSampleNaN sets a sample to not a number with an error status
 // E.g. a ping failed, RTT has to be set to NaN
 func (db *Database) SetSampleNaN(id uint32) {
 	// Create a write transaction
@@ -51,8 +57,10 @@ func (db *Database) SetSampleNaN(id uint32) {
 		return
 	}
 
-	sample.Value = "NaN"
+	sample.Value = math.NaN()
+	sample.Status = SampleStatusError
 	sample.Ts = time.Now().Unix()
+	sample.Seq = db.seq.Add(1)
 	err := txn.Insert("sample", &sample)
 	if err != nil {
 		panic(err)
@@ -60,6 +68,7 @@ func (db *Database) SetSampleNaN(id uint32) {
 
 	// Commit the transaction
 	txn.Commit()
+	db.addHistory(&sample)
 }
 
 // GetSample returns a measurement sample by id
@@ -88,6 +97,7 @@ func (db *Database) DeleteSample(id uint32) {
 	}
 	// Commit the transaction
 	txn.Commit()
+	db.deleteHistory(id)
 }
 
 // GetSampleTs returns the timestamp from a measurement sample by id
@@ -120,3 +130,20 @@ func (db *Database) GetSampleList() []*Sample {
 	}
 	return samples
 }
+
+// GetSampleListSince returns all measurement samples
+// changed after the given sequence number
+func (db *Database) GetSampleListSince(seq uint64) []*Sample {
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.LowerBound("sample", "seq", seq+1)
+	if err != nil {
+		panic(err)
+	}
+	var samples []*Sample
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		samples = append(samples, obj.(*Sample))
+	}
+	return samples
+}
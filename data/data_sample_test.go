@@ -22,6 +22,7 @@
 package data
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -53,9 +54,12 @@ func Test_SetSampleNaN(t *testing.T) {
 	txn := db.Txn(false)
 	raw, _ := txn.Get("sample", "id")
 	for obj := raw.Next(); obj != nil; obj = raw.Next() {
-		if obj.(*Sample).Value != "NaN" {
+		if !math.IsNaN(obj.(*Sample).Value) {
 			t.Errorf("The sample value is not Nan as expected. Sample value: %v", obj.(*Sample).Value)
 		}
+		if obj.(*Sample).Status != SampleStatusError {
+			t.Errorf("The sample status is not error as expected. Sample status: %v", obj.(*Sample).Status)
+		}
 	}
 }
 
@@ -149,3 +153,28 @@ func Test_SetSampleTsNow(t *testing.T) {
 		t.Errorf("no nodes set in db, %v nodes should be set", len(nodes))
 	}
 }
+
+func Test_GetSampleListSince(t *testing.T) {
+	db, _ := NewMemDB(log)
+	for _, sample := range samples {
+		db.SetSample(sample)
+	}
+
+	result := db.GetSampleListSince(0)
+	if len(result) != len(samples) {
+		t.Errorf("the amount returned samples is incorrect: %v but expected %v", len(result), len(samples))
+	}
+
+	// change the first sample, just this one is newer than the last sequence
+	seq := samples[len(samples)-1].Seq
+	db.SetSampleNaN(GetSampleId(samples[0]))
+	result = db.GetSampleListSince(seq)
+	if len(result) != 1 || result[0].Id != GetSampleId(samples[0]) {
+		t.Errorf("just the changed sample should be returned, got: %+v", result)
+	}
+
+	result = db.GetSampleListSince(result[0].Seq)
+	if len(result) != 0 {
+		t.Errorf("no sample should be returned, got %v samples", len(result))
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+)
+
+// snapshot is the on-disk representation of the database
+type snapshot struct {
+	Nodes   []*Node
+	Samples []*Sample
+}
+
+// SaveSnapshot writes all nodes and samples of the store to the file.
+// The file is replaced atomically, a failed write keeps the last snapshot.
+func SaveSnapshot(store Store, path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	err = gob.NewEncoder(file).Encode(snapshot{
+		Nodes:   store.GetNodeList(),
+		Samples: store.GetSampleList(),
+	})
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// LoadSnapshot inserts the nodes and samples of the file into the store.
+// It returns the amount of loaded nodes and samples.
+func LoadSnapshot(store Store, path string) (int, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	var s snapshot
+	if err = gob.NewDecoder(file).Decode(&s); err != nil {
+		return 0, 0, err
+	}
+
+	for _, node := range s.Nodes {
+		store.SetNode(node)
+	}
+	for _, sample := range s.Samples {
+		store.SetSample(sample)
+	}
+	return len(s.Nodes), len(s.Samples), nil
+}
@@ -0,0 +1,81 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func Test_Snapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "canary.snapshot")
+
+	db, _ := NewMemDB(log)
+	db.SetNode(&Node{Id: 1, Name: "owl", Target: "owl:8081", State: 1, StateChangeTs: 10, Labels: map[string]string{"zone": "a"}})
+	db.SetNode(&Node{Id: 2, Name: "swan", Target: "swan:8081", State: 3, StateChangeTs: 20})
+	db.SetSample(&Sample{From: "owl", To: "swan", Key: RttTotal, Value: 42, Status: SampleStatusOk, Unit: UnitNanoseconds, Ts: 30})
+	db.SetSample(&Sample{From: "swan", To: "owl", Key: RttTotal, Value: math.NaN(), Status: SampleStatusError, Unit: UnitNanoseconds, Ts: 40})
+
+	if err := SaveSnapshot(db, path); err != nil {
+		t.Fatalf("could not save snapshot: %v", err)
+	}
+
+	restored, _ := NewMemDB(log)
+	nodes, samples, err := LoadSnapshot(restored, path)
+	if err != nil {
+		t.Fatalf("could not load snapshot: %v", err)
+	}
+	if nodes != 2 || samples != 2 {
+		t.Errorf("loaded %d nodes and %d samples, expected 2 and 2", nodes, samples)
+	}
+	if diff := deep.Equal(restored.GetNodeList(), db.GetNodeList()); diff != nil {
+		t.Error(diff)
+	}
+
+	sample := restored.GetSample(GetSampleId(&Sample{From: "owl", To: "swan", Key: RttTotal}))
+	if sample.Value != 42 || sample.Status != SampleStatusOk || sample.Unit != UnitNanoseconds || sample.Ts != 30 {
+		t.Errorf("restored sample %+v is not as expected", sample)
+	}
+	sample = restored.GetSample(GetSampleId(&Sample{From: "swan", To: "owl", Key: RttTotal}))
+	if !math.IsNaN(sample.Value) || sample.Status != SampleStatusError {
+		t.Errorf("restored failed sample %+v is not as expected", sample)
+	}
+
+	// no temporary files are left
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("expected only the snapshot file, got %d files", len(files))
+	}
+}
+
+func Test_LoadSnapshotMissing(t *testing.T) {
+	db, _ := NewMemDB(log)
+	_, _, err := LoadSnapshot(db, filepath.Join(t.TempDir(), "missing.snapshot"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"math"
+	"sort"
+)
+
+// SampleStats summarises the history of a sample series
+type SampleStats struct {
+	Count     int
+	Min       float64
+	Max       float64
+	Mean      float64
+	P50       float64
+	P90       float64
+	P99       float64
+	LossRatio float64
+}
+
+// GetSampleStats calculates the statistics of the history entries measured since the unix timestamp.
+// Entries without ok status count as lost; the value statistics are NaN if there is no ok entry.
+func GetSampleStats(entries []HistoryEntry, since int64) SampleStats {
+	values := []float64{}
+	count := 0
+	for _, entry := range entries {
+		if entry.Ts < since {
+			continue
+		}
+		count++
+		if entry.Status == SampleStatusOk && !math.IsNaN(entry.Value) {
+			values = append(values, entry.Value)
+		}
+	}
+
+	stats := SampleStats{
+		Count: count,
+		Min:   math.NaN(),
+		Max:   math.NaN(),
+		Mean:  math.NaN(),
+		P50:   math.NaN(),
+		P90:   math.NaN(),
+		P99:   math.NaN(),
+	}
+	if count == 0 {
+		stats.LossRatio = math.NaN()
+		return stats
+	}
+	stats.LossRatio = float64(count-len(values)) / float64(count)
+	if len(values) == 0 {
+		return stats
+	}
+
+	sort.Float64s(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	stats.Min = values[0]
+	stats.Max = values[len(values)-1]
+	stats.Mean = sum / float64(len(values))
+	stats.P50 = percentile(values, 50)
+	stats.P90 = percentile(values, 90)
+	stats.P99 = percentile(values, 99)
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,92 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func Test_GetSampleStats(t *testing.T) {
+	ok := func(value float64, ts int64) HistoryEntry {
+		return HistoryEntry{Value: value, Status: SampleStatusOk, Ts: ts}
+	}
+	failed := func(ts int64) HistoryEntry {
+		return HistoryEntry{Value: math.NaN(), Status: SampleStatusTimeout, Ts: ts}
+	}
+
+	tests := []struct {
+		name     string
+		entries  []HistoryEntry
+		since    int64
+		expected SampleStats
+	}{
+		{
+			name:     "Single entry",
+			entries:  []HistoryEntry{ok(5, 1)},
+			expected: SampleStats{Count: 1, Min: 5, Max: 5, Mean: 5, P50: 5, P90: 5, P99: 5},
+		},
+		{
+			name:     "Unsorted entries",
+			entries:  []HistoryEntry{ok(4, 1), ok(1, 2), ok(3, 3), ok(2, 4)},
+			expected: SampleStats{Count: 4, Min: 1, Max: 4, Mean: 2.5, P50: 2, P90: 4, P99: 4},
+		},
+		{
+			name:     "Failed entries count as loss",
+			entries:  []HistoryEntry{ok(2, 1), failed(2), ok(4, 3), failed(4)},
+			expected: SampleStats{Count: 4, Min: 2, Max: 4, Mean: 3, P50: 2, P90: 4, P99: 4, LossRatio: 0.5},
+		},
+		{
+			name:     "Entries before window",
+			entries:  []HistoryEntry{failed(1), ok(100, 2), ok(1, 3), ok(3, 4)},
+			since:    3,
+			expected: SampleStats{Count: 2, Min: 1, Max: 3, Mean: 2, P50: 1, P90: 3, P99: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(GetSampleStats(tt.entries, tt.since), tt.expected); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_GetSampleStatsWithoutValues(t *testing.T) {
+	stats := GetSampleStats([]HistoryEntry{{Value: math.NaN(), Status: SampleStatusError, Ts: 1}}, 0)
+	if stats.Count != 1 || stats.LossRatio != 1 {
+		t.Errorf("expected count 1 and loss ratio 1, got %d and %f", stats.Count, stats.LossRatio)
+	}
+	for _, value := range []float64{stats.Min, stats.Max, stats.Mean, stats.P50, stats.P90, stats.P99} {
+		if !math.IsNaN(value) {
+			t.Errorf("expected NaN value statistics, got %+v", stats)
+		}
+	}
+
+	stats = GetSampleStats([]HistoryEntry{}, 0)
+	if stats.Count != 0 || !math.IsNaN(stats.LossRatio) {
+		t.Errorf("expected count 0 and NaN loss ratio, got %+v", stats)
+	}
+}
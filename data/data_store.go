@@ -0,0 +1,49 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+// Store holds the nodes and samples of the mesh.
+// Database is the in-memory implementation.
+//
+//go:generate moq -out data_test_moq.go . Store
+type Store interface {
+	SetNode(node *Node)
+	SetNodeTsNow(id uint32)
+	DeleteNode(id uint32)
+	GetNode(id uint32) *Node
+	GetNodeByName(name string) *Node
+	GetNodeList() []*Node
+	GetNodeListByState(byState int) []*Node
+	GetRandomNodeListByState(byState int, amountOfNodes int, without ...uint32) []*Node
+
+	SetSample(sample *Sample)
+	SetSampleNaN(id uint32)
+	GetSample(id uint32) *Sample
+	DeleteSample(id uint32)
+	GetSampleTs(id uint32) int64
+	GetSampleList() []*Sample
+	GetSampleListSince(seq uint64) []*Sample
+	GetSampleHistory(id uint32) []HistoryEntry
+}
+
+// Database implements the Store
+var _ Store = &Database{}
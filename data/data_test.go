@@ -22,6 +22,7 @@
 package data
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -57,9 +58,9 @@ func init() {
 	}
 
 	samples = []*Sample{
-		{Id: 1, From: "node_1", To: "node_2", Key: 1, Value: "12345", Ts: 1},
-		{Id: 2, From: "node_1", To: "node_3", Key: 1, Value: "454545", Ts: 2},
-		{Id: 3, From: "node_2", To: "node_3", Key: 2, Value: "8910", Ts: 3},
+		{Id: 1, From: "node_1", To: "node_2", Key: 1, Value: 12345, Status: SampleStatusOk, Ts: 1},
+		{Id: 2, From: "node_1", To: "node_3", Key: 1, Value: 454545, Status: SampleStatusOk, Ts: 2},
+		{Id: 3, From: "node_2", To: "node_3", Key: 2, Value: 8910, Status: SampleStatusOk, Unit: UnitNanoseconds, Ts: 3},
 	}
 }
 
@@ -87,8 +88,9 @@ func Test_Convert(t *testing.T) {
 		{
 			name: "MeshNode to Node",
 			inputMeshNode: &meshv1.Node{
-				Name:   "test",
-				Target: "tegraT",
+				Name:        "test",
+				Target:      "tegraT",
+				Incarnation: 4,
 			},
 			state: 1,
 			expectedNode: &Node{
@@ -97,6 +99,7 @@ func Test_Convert(t *testing.T) {
 				State:         1,
 				Target:        "tegraT",
 				StateChangeTs: 0,
+				Incarnation:   4,
 			},
 		},
 		{
@@ -107,10 +110,12 @@ func Test_Convert(t *testing.T) {
 				State:         12,
 				Target:        "tegraT",
 				StateChangeTs: time.Now().Unix(),
+				Incarnation:   7,
 			},
 			expectedMeshNode: &meshv1.Node{
-				Name:   "test",
-				Target: "tegraT",
+				Name:        "test",
+				Target:      "tegraT",
+				Incarnation: 7,
 			},
 		},
 	}
@@ -122,7 +127,8 @@ func Test_Convert(t *testing.T) {
 				if result.Id != tt.expectedNode.Id ||
 					result.Name != tt.expectedNode.Name ||
 					result.Target != tt.expectedNode.Target ||
-					result.State != tt.expectedNode.State {
+					result.State != tt.expectedNode.State ||
+					result.Incarnation != tt.expectedNode.Incarnation {
 					diff := deep.Equal(result, tt.expectedNode)
 					t.Error(diff)
 				}
@@ -196,3 +202,58 @@ func Test_GetSampleId(t *testing.T) {
 		})
 	}
 }
+
+func Test_RegisterSampleName(t *testing.T) {
+	if err := RegisterSampleName(1001, "custom"); err != nil {
+		t.Errorf("could not register sample name: %v", err)
+	}
+	if err := RegisterSampleName(1001, "custom"); err != nil {
+		t.Errorf("registering the same name twice should be fine: %v", err)
+	}
+	if err := RegisterSampleName(RttTotal, "custom"); err == nil {
+		t.Error("an already registered sample key was overwritten")
+	}
+	if SampleName[RttTotal] != "rtt_total" {
+		t.Errorf("sample name of rtt_total changed to %v", SampleName[RttTotal])
+	}
+}
+
+func Test_ConvertSample(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *meshv1.Sample
+		expected *Sample
+	}{
+		{
+			name: "Numeric sample",
+			input: &meshv1.Sample{
+				From: "owl", To: "swan", Key: RttTotal, Value: "12345", Ts: 1,
+				NumericValue: 12345, Status: meshv1.SampleStatus_SAMPLE_STATUS_OK, Unit: UnitNanoseconds,
+			},
+			expected: &Sample{From: "owl", To: "swan", Key: RttTotal, Value: 12345, Status: SampleStatusOk, Unit: UnitNanoseconds, Ts: 1},
+		},
+		{
+			name:     "String sample of an old node",
+			input:    &meshv1.Sample{From: "owl", To: "swan", Key: RttTotal, Value: "12345", Ts: 1},
+			expected: &Sample{From: "owl", To: "swan", Key: RttTotal, Value: 12345, Status: SampleStatusOk, Unit: UnitNanoseconds, Ts: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(ConvertSample(tt.input), tt.expected); diff != nil {
+				t.Error(diff)
+			}
+			// the string value is kept for old nodes
+			if diff := deep.Equal(tt.expected.Convert().Value, tt.input.Value); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	// failed sample of an old node
+	sample := ConvertSample(&meshv1.Sample{From: "owl", To: "swan", Key: RttTotal, Value: "NaN", Ts: 1})
+	if !math.IsNaN(sample.Value) || sample.Status != SampleStatusError {
+		t.Errorf("The sample is not NaN with error status as expected: %+v", sample)
+	}
+}
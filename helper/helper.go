@@ -114,7 +114,23 @@ func GenerateRandomToken(length int64) string {
 // a transport credentials object for gRPC usage.
 func LoadClientTLSCredentials(cacertPaths []string, cacertB64 []byte) (credentials.TransportCredentials, error) {
 	// Load certificate of the CA who signed server certificate
+	certPool, err := LoadCaCertPool(cacertPaths, cacertB64)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the credentials and return it
+	config := &tls.Config{
+		RootCAs:    certPool,
+		MinVersion: tls.VersionTLS12,
+	}
 
+	return credentials.NewTLS(config), nil
+}
+
+// LoadCaCertPool loads the ca certificates from disk or the base64 encoded ca cert
+// into a certificate pool.
+func LoadCaCertPool(cacertPaths []string, cacertB64 []byte) (*x509.CertPool, error) {
 	certPool := x509.NewCertPool()
 
 	if len(cacertPaths) > 0 {
@@ -138,13 +154,7 @@ func LoadClientTLSCredentials(cacertPaths []string, cacertB64 []byte) (credentia
 		return nil, errors.New("Neither ca cert path nor base64 encoded ca cert set")
 	}
 
-	// Create the credentials and return it
-	config := &tls.Config{
-		RootCAs:    certPool,
-		MinVersion: tls.VersionTLS12,
-	}
-
-	return credentials.NewTLS(config), nil
+	return certPool, nil
 }
 
 // LoadServerTLSCredentials loads a certificate from disk and creates
@@ -48,6 +48,7 @@ All bots will gather data and spread it in the mesh.
 
 Features:
  - auto re-join
+ - graceful leave on SIGTERM / SIGINT
  - http based communication (gRPC)
  - partly based on the gossip protocol standard
  - mutual TLS, edge-terminating TLS, no TLS
@@ -70,11 +71,12 @@ cbot --name swan -t bird-goose.com:443 -t bird-eagle.net:8080 --ca-cert-path pat
 var (
 	defaults mesh.SetupConfiguration
 	set      mesh.SetupConfiguration
+	routine  = mesh.StandardProductionRoutineConfig()
 )
 
 // run is the main function of the canary-bot which executes the mesh creation.
 func run(cmd *cobra.Command, args []string) {
-	mesh.CreateCanaryMesh(mesh.StandardProductionRoutineConfig(), &set)
+	mesh.CreateCanaryMesh(routine, &set)
 }
 
 func main() {
@@ -90,23 +92,37 @@ func main() {
 // All cmd flags will be defined.
 func init() {
 	defaults = mesh.SetupConfiguration{
-		Targets:        []string{},
-		Name:           "",
-		JoinAddress:    "",
-		ListenAddress:  "",
-		ListenPort:     8081,
-		ApiPort:        8080,
-		ServerCertPath: "",
-		ServerKeyPath:  "",
-		ServerCert:     nil,
-		ServerKey:      nil,
-		CaCertPath:     []string{},
-		CaCert:         nil,
-		Tokens:         []string{},
-		CleanupNodes:   false,
-		CleanupSamples: false,
-		Debug:          false,
-		DebugGrpc:      false,
+		Targets:           []string{},
+		Name:              "",
+		JoinAddress:       "",
+		ListenAddress:     "",
+		ListenPort:        8081,
+		Labels:            map[string]string{},
+		HttpTargets:       []string{},
+		HttpBodyMatch:     "",
+		UdpEchoPort:       0,
+		TcpTargets:        []string{},
+		DnsTargets:        []string{},
+		DnsResolvers:      []string{},
+		DnsExpect:         map[string]string{},
+		TlsTargets:        []string{},
+		Throughput:        false,
+		GrpcHealthTargets: []string{},
+		ExecCommands:      map[string]string{},
+		ExecEnv:           map[string]string{},
+		ApiPort:           8080,
+		ServerCertPath:    "",
+		ServerKeyPath:     "",
+		ServerCert:        nil,
+		ServerKey:         nil,
+		CaCertPath:        []string{},
+		CaCert:            nil,
+		Tokens:            []string{},
+		CleanupNodes:      false,
+		CleanupSamples:    false,
+		SnapshotPath:      "",
+		Debug:             false,
+		DebugGrpc:         false,
 	}
 
 	// Targets for joining
@@ -117,6 +133,35 @@ func init() {
 	cmd.Flags().StringVar(&set.ListenAddress, "listen-address", defaults.ListenAddress, "Address or IP the server of the node will bind to; eg. 0.0.0.0, localhost (default outbound IP of the network interface)")
 	cmd.Flags().Int64Var(&set.ListenPort, "listen-port", defaults.ListenPort, "Listening port of this node")
 	cmd.Flags().StringVar(&set.JoinAddress, "join-address", defaults.JoinAddress, "Address of this node; nodes in the mesh will use the domain to connect; eg. test.de, localhost (default outbound IP of the network interface)")
+	cmd.Flags().StringToStringVarP(&set.Labels, "label", "l", defaults.Labels, "Comma-seperated or multi-flag list of labels of this node, added to the RTT metrics.\nFormat: KEY=VALUE e.g. zone=eu-central-1,cluster=prod")
+
+	// HTTP(S) probe
+	cmd.Flags().StringSliceVar(&set.HttpTargets, "http-target", defaults.HttpTargets, "Comma-seperated or multi-flag list of external HTTP(S) URLs to probe.\nFormat: http[s]://ADDRESS[:PORT][/PATH]")
+	cmd.Flags().StringVar(&set.HttpBodyMatch, "http-body-match", defaults.HttpBodyMatch, "Regular expression the response body of the HTTP(S) targets has to match (optional)")
+
+	// Packet loss probe
+	cmd.Flags().Int64Var(&set.UdpEchoPort, "udp-echo-port", defaults.UdpEchoPort, "UDP port of the echo listener for the packet loss probe, has to be equal on all nodes (default disabled)")
+
+	// TCP connect probe
+	cmd.Flags().StringSliceVar(&set.TcpTargets, "tcp-target", defaults.TcpTargets, "Comma-seperated or multi-flag list of host:port targets for the TCP connect probe.\nFormat: [IP|ADDRESS]:PORT")
+
+	// DNS probe
+	cmd.Flags().StringSliceVar(&set.DnsTargets, "dns-target", defaults.DnsTargets, "Comma-seperated or multi-flag list of hostnames for the DNS probe")
+	cmd.Flags().StringSliceVar(&set.DnsResolvers, "dns-resolver", defaults.DnsResolvers, "Comma-seperated or multi-flag list of resolvers queried besides the system resolver.\nFormat: IP[:PORT]")
+	cmd.Flags().StringToStringVar(&set.DnsExpect, "dns-expect", defaults.DnsExpect, "Comma-seperated or multi-flag list of expected answers per hostname.\nFormat: HOSTNAME=ANSWER|ANSWER e.g. example.com=192.0.2.1|192.0.2.2")
+
+	// TLS probe
+	cmd.Flags().StringSliceVar(&set.TlsTargets, "tls-target", defaults.TlsTargets, "Comma-seperated or multi-flag list of TLS endpoints for the TLS probe, peers are probed if a ca cert is set.\nFormat: [IP|ADDRESS]:PORT")
+
+	// Throughput probe
+	cmd.Flags().BoolVar(&set.Throughput, "throughput", defaults.Throughput, "Enable the throughput probe to a random node (default disabled)")
+
+	// gRPC health probe
+	cmd.Flags().StringSliceVar(&set.GrpcHealthTargets, "grpc-health-target", defaults.GrpcHealthTargets, "Comma-seperated or multi-flag list of targets for the gRPC health probe.\nFormat: [IP|ADDRESS]:PORT[/SERVICE]")
+
+	// Exec probe
+	cmd.Flags().StringToStringVar(&set.ExecCommands, "exec", defaults.ExecCommands, "Multi-flag list of local commands for the exec probe, executed with sh -c.\nFormat: NAME=COMMAND e.g. queue=./check-queue.sh")
+	cmd.Flags().StringToStringVar(&set.ExecEnv, "exec-env", defaults.ExecEnv, "Comma-seperated or multi-flag list of environment variables for the exec probe.\nFormat: KEY=VALUE")
 
 	// API
 	cmd.Flags().Int64VarP(&set.ApiPort, "api-port", "p", defaults.ApiPort, "API port of this node")
@@ -135,9 +180,17 @@ func init() {
 	cmd.Flags().StringSliceVar(&set.Tokens, "token", defaults.Targets, "Comma-seperated or multi-flag list of tokens to protect the sample data API. (optional)")
 
 	// Cleanup database mode
-	cmd.Flags().BoolVar(&set.CleanupNodes, "cleanup-nodes", defaults.CleanupNodes, "Enable cleanup mode for nodes (default disabled)")
+	cmd.Flags().BoolVar(&set.CleanupNodes, "cleanup-nodes", defaults.CleanupNodes, "Enable cleanup mode for nodes (deprecated, removed nodes are always deleted)")
+	_ = cmd.Flags().MarkDeprecated("cleanup-nodes", "removed nodes and their samples are always deleted after the tombstone ttl")
 	cmd.Flags().BoolVar(&set.CleanupSamples, "cleanup-samples", defaults.CleanupSamples, "Enable cleanup mode for measurement samples (default disabled)")
 
+	// Sample history
+	cmd.Flags().DurationVar(&routine.SampleHistoryMaxAge, "history-max-age", routine.SampleHistoryMaxAge, "Time window of the local sample history per series e.g. 15m, 1h")
+	cmd.Flags().IntVar(&routine.SampleHistorySize, "history-size", routine.SampleHistorySize, "Maximum amount of history entries per series; 0 derives it from history-max-age divided by the RTT interval of 3s e.g. 1200 entries for 1h, a negative size disables the history")
+
+	// Persistence
+	cmd.Flags().StringVar(&set.SnapshotPath, "snapshot-path", defaults.SnapshotPath, "Path to the snapshot file of nodes and samples, reloaded on start to rejoin known nodes (default disabled)")
+
 	// Logging mode
 	cmd.Flags().BoolVar(&set.Debug, "debug", defaults.Debug, "Set logging to debug mode")
 	cmd.Flags().BoolVar(&set.DebugGrpc, "debug-grpc", defaults.DebugGrpc, "Enable more logging for grpc")
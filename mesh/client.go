@@ -23,7 +23,9 @@ package mesh
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/telekom/canary-bot/data"
@@ -33,7 +35,6 @@ import (
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // MeshClient is the client for the mesh service
@@ -64,12 +65,9 @@ func (m *Mesh) Join(targets []string) (bool, bool) {
 		}
 
 		// send join mesh request
-		res, err = m.clients[GetId(node)].client.JoinMesh(
+		res, err = m.client(node).JoinMesh(
 			context.Background(),
-			&meshv1.Node{
-				Name:   m.setupConfig.Name,
-				Target: m.setupConfig.JoinAddress,
-			})
+			m.ownNode())
 
 		if err != nil {
 			m.logger.Debug("Client connected, but joinMesh request failed")
@@ -91,19 +89,98 @@ func (m *Mesh) Join(targets []string) (bool, bool) {
 		m.database.SetNode(data.Convert(node, NodeOk))
 
 		log.Infow("Joined mesh", "name", node.Name, "target", node.Target)
+
+		// get all samples of the mesh right away
+		go m.PullState(node)
 		break
 	}
 	for _, node := range res.Nodes {
-		if GetId(node) != GetId(&meshv1.Node{
-			Name:   m.setupConfig.Name,
-			Target: m.setupConfig.JoinAddress,
-		}) {
+		if GetId(node) != GetId(m.ownNode()) {
 			m.database.SetNode(data.Convert(node, NodeOk))
 		}
 	}
 	return true, true
 }
 
+// Reconcile pulls the state of the targets, even if this node is already in a mesh.
+// Unknown nodes of the responses will be added and gossiped,
+// the targets will discover this node if it is unknown to them.
+// A split mesh will converge back into one.
+func (m *Mesh) Reconcile(targets []string) {
+	for _, target := range targets {
+		// don't reconcile with yourself
+		if target == m.setupConfig.JoinAddress {
+			continue
+		}
+		m.PullState(&meshv1.Node{Name: "", Target: target})
+	}
+}
+
+// mergeNodes adds unknown nodes or nodes with a newer incarnation
+// as healthy nodes and gossips them.
+func (m *Mesh) mergeNodes(nodes []*meshv1.Node) {
+	log := m.logger.Named("reconcile-routine")
+
+	for _, node := range nodes {
+		if node.Name == m.setupConfig.Name {
+			continue
+		}
+
+		known := m.database.GetNodeByName(node.Name)
+		// same name, but another target; just one of them can be in the database
+		if known.Id != 0 && known.Target != node.Target {
+			continue
+		}
+		if !overridesNodeState(NodeOk, node.Incarnation, known) {
+			continue
+		}
+
+		log.Infow("Node discovered by reconciliation", "node", node.Name)
+		m.database.SetNode(data.Convert(node, NodeOk))
+		m.gossipNodeState(node, NodeOk)
+	}
+}
+
+// reviveNode adds a removed node as healthy again, its direct response proves it alive.
+// Other nodes keep the tombstone until the node refutes it with a newer incarnation.
+func (m *Mesh) reviveNode(node *meshv1.Node) {
+	known := m.database.GetNodeByName(node.Name)
+	if !isRemoved(known) || known.Target != node.Target || node.Incarnation < known.Incarnation {
+		return
+	}
+	m.logger.Named("pull-routine").Infow("Removed node responded - node is alive", "node", node.Name, "state", known.State)
+	m.database.SetNode(data.Convert(node, NodeOk))
+}
+
+// Leave tells all known nodes that this node is leaving the mesh.
+func (m *Mesh) Leave() {
+	log := m.logger.Named("leave-routine")
+
+	var wg sync.WaitGroup
+	for _, node := range m.database.GetNodeList() {
+		if isRemoved(node) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(node *meshv1.Node) {
+			defer wg.Done()
+			err := m.initClient(node)
+			if err != nil {
+				log.Debugw("Could not connect to client - skip Leave Mesh Request", "node", node.Name)
+				return
+			}
+			_, err = m.client(node).LeaveMesh(context.Background(), m.ownNode())
+			if err != nil {
+				log.Debugw("Could not send Leave Mesh Request", "node", node.Name, "error", err)
+				return
+			}
+			log.Infow("Left mesh", "node", node.Name)
+		}(node.Convert())
+	}
+	wg.Wait()
+}
+
 func (m *Mesh) ping(node *meshv1.Node) error {
 	log := m.logger.Named("ping-routine")
 	err := m.initClient(node)
@@ -111,12 +188,9 @@ func (m *Mesh) ping(node *meshv1.Node) error {
 		log.Debugw("Could not connect to client")
 		return err
 	}
-	_, err = m.clients[GetId(node)].client.Ping(
+	_, err = m.client(node).Ping(
 		context.Background(),
-		&meshv1.Node{
-			Name:   m.setupConfig.Name,
-			Target: m.setupConfig.JoinAddress,
-		})
+		m.ownNode())
 	if err != nil {
 		log.Debugw("Ping failed")
 		return err
@@ -125,6 +199,37 @@ func (m *Mesh) ping(node *meshv1.Node) error {
 	return nil
 }
 
+// pingReq asks the via node to ping the target node on behalf of this node.
+func (m *Mesh) pingReq(via *meshv1.Node, target *meshv1.Node) error {
+	log := m.logger.Named("ping-routine")
+	err := m.initClient(via)
+	if err != nil {
+		log.Debugw("Could not connect to client")
+		return err
+	}
+
+	// the via node needs time for its own ping to the target
+	ctx, cancel := context.WithTimeout(context.Background(), 2*m.routineConfig.RequestTimeout)
+	defer cancel()
+
+	res, err := m.client(via).PingReq(
+		ctx,
+		&meshv1.PingReqRequest{
+			Target:  target,
+			IAmNode: m.ownNode(),
+		})
+	if err != nil {
+		log.Debugw("Ping request failed", "via", via.Name, "error", err)
+		return err
+	}
+	if !res.Ok {
+		log.Debugw("Indirect ping failed", "via", via.Name, "node", target.Name)
+		return errors.New("node " + target.Name + " not reachable via " + via.Name)
+	}
+
+	return nil
+}
+
 func (m *Mesh) NodeDiscovery(toNode *meshv1.Node, newNode *meshv1.Node) {
 	log := m.logger.Named("discovery-routine")
 	err := m.initClient(toNode)
@@ -132,7 +237,7 @@ func (m *Mesh) NodeDiscovery(toNode *meshv1.Node, newNode *meshv1.Node) {
 		log.Warnw("Could not connect to client - skip Node Discover Request", "node", toNode.Name)
 		return
 	}
-	_, err = m.clients[GetId(toNode)].client.NodeDiscovery(
+	_, err = m.client(toNode).NodeDiscovery(
 		context.Background(),
 		&meshv1.NodeDiscoveryRequest{
 			NewNode: newNode,
@@ -147,6 +252,71 @@ func (m *Mesh) NodeDiscovery(toNode *meshv1.Node, newNode *meshv1.Node) {
 	return
 }
 
+// NodeStateUpdate sends the state of a node to another node.
+func (m *Mesh) NodeStateUpdate(toNode *meshv1.Node, node *meshv1.Node, state int) {
+	log := m.logger.Named("state-routine")
+	err := m.initClient(toNode)
+	if err != nil {
+		log.Warnw("Could not connect to client - skip Node State Update Request", "node", toNode.Name)
+		return
+	}
+	_, err = m.client(toNode).NodeStateUpdate(
+		context.Background(),
+		&meshv1.NodeStateUpdateRequest{
+			Node:    node,
+			State:   int64(state),
+			IAmNode: m.ownNode(),
+		})
+	if err != nil {
+		log.Warnw("Could not start request to client - skip Node State Update Request", "node", toNode.Name, "error", err)
+	}
+}
+
+// sampleWatermark holds the state of the sample pushes to a node
+type sampleWatermark struct {
+	// seq is the highest sequence number acknowledged by the node
+	seq uint64
+	// fullSyncTs is the time of the last acknowledged push of all samples
+	fullSyncTs time.Time
+}
+
+// getSampleWatermark returns a copy of the sample watermark of a node
+func (m *Mesh) getSampleWatermark(id uint32) sampleWatermark {
+	m.watermarkMu.Lock()
+	defer m.watermarkMu.Unlock()
+
+	if watermark, exists := m.sampleWatermarks[id]; exists {
+		return *watermark
+	}
+	return sampleWatermark{}
+}
+
+// setSampleWatermark sets the acknowledged sequence number of a node
+func (m *Mesh) setSampleWatermark(id uint32, seq uint64, fullSync bool) {
+	m.watermarkMu.Lock()
+	defer m.watermarkMu.Unlock()
+
+	watermark, exists := m.sampleWatermarks[id]
+	if !exists {
+		watermark = &sampleWatermark{}
+		m.sampleWatermarks[id] = watermark
+	}
+	watermark.seq = seq
+	if fullSync {
+		watermark.fullSyncTs = time.Now()
+	}
+}
+
+// resetSampleWatermark lets the next push to a node contain all samples
+func (m *Mesh) resetSampleWatermark(id uint32) {
+	m.watermarkMu.Lock()
+	defer m.watermarkMu.Unlock()
+
+	delete(m.sampleWatermarks, id)
+}
+
+// pushSamples pushes the samples changed since the last acknowledged push to the node.
+// All samples will be pushed, if the last full push is older than the full sync interval.
 func (m *Mesh) pushSamples(node *meshv1.Node) error {
 	log := m.logger.Named("sample-routine")
 	err := m.initClient(node)
@@ -155,24 +325,98 @@ func (m *Mesh) pushSamples(node *meshv1.Node) error {
 		return err
 	}
 
-	var samples []*meshv1.Sample
-	databaseSamples := m.database.GetSampleList()
+	watermark := m.getSampleWatermark(GetId(node))
+	fullSync := time.Since(watermark.fullSyncTs) > m.routineConfig.PushSampleFullSyncInterval
+
+	var databaseSamples []*data.Sample
+	if fullSync {
+		databaseSamples = m.database.GetSampleList()
+	} else {
+		databaseSamples = m.database.GetSampleListSince(watermark.seq)
+	}
 	if len(databaseSamples) == 0 {
-		log.Debugw("No samples found for push - will not push")
+		log.Debugw("No new samples found for push - will not push")
 		return nil
 	}
-	for _, sample := range m.database.GetSampleList() {
-		samples = append(samples, &meshv1.Sample{From: sample.From, To: sample.To, Key: sample.Key, Value: sample.Value, Ts: sample.Ts})
+
+	var samples []*meshv1.Sample
+	var seq uint64
+	for _, sample := range databaseSamples {
+		samples = append(samples, sample.Convert())
+		if sample.Seq > seq {
+			seq = sample.Seq
+		}
 	}
 
-	_, err = m.clients[GetId(node)].client.PushSamples(context.Background(), &meshv1.Samples{Samples: samples})
+	res, err := m.client(node).PushSamples(context.Background(), &meshv1.Samples{Samples: samples, Seq: seq})
 	if err != nil {
 		log.Debugw("Could not send samples", "error", err)
 		return err
 	}
+
+	// nodes without acknowledgement support will receive all samples again
+	log.Debugw("Samples pushed", "node", node.Name, "count", len(samples), "accepted", res.Accepted, "fullSync", fullSync)
+	m.setSampleWatermark(GetId(node), res.Seq, fullSync)
 	return nil
 }
 
+// PullState sends a digest of the known samples and nodes to the node (anti-entropy).
+// The missing or stale samples and nodes of the response will be added.
+func (m *Mesh) PullState(node *meshv1.Node) {
+	log := m.logger.Named("pull-routine")
+	err := m.initClient(node)
+	if err != nil {
+		log.Debugw("Could not connect to client - skip Pull State Request", "node", node.Name)
+		return
+	}
+
+	digest := &meshv1.StateDigest{
+		Samples: map[uint32]int64{},
+		Nodes:   map[string]uint64{},
+		IAmNode: m.ownNode(),
+	}
+	for _, sample := range m.database.GetSampleList() {
+		digest.Samples[sample.Id] = sample.Ts
+	}
+	for _, known := range m.database.GetNodeList() {
+		digest.Nodes[known.Name] = known.Incarnation
+	}
+
+	res, err := m.client(node).PullState(context.Background(), digest)
+	if err != nil {
+		log.Debugw("Could not pull state", "node", node.Name, "error", err)
+		return
+	}
+
+	// the responding node is added as well, e.g. a reconciled target
+	nodes := res.Nodes
+	if res.IAmNode != nil {
+		m.reviveNode(res.IAmNode)
+		nodes = append(nodes, res.IAmNode)
+	}
+	m.mergeNodes(nodes)
+
+	// nodes are merged first, samples of unknown nodes are rejected
+	for _, sample := range res.Samples {
+		if !isSampleAccepted(m.database, m.setupConfig.Name, m.routineConfig.TombstoneTTL, sample) {
+			continue
+		}
+		if sample.Ts > m.database.GetSampleTs(GetSampleId(sample)) {
+			m.database.SetSample(data.ConvertSample(sample))
+		}
+	}
+
+	// removed nodes are handled like gossiped states
+	for _, tombstone := range res.Tombstones {
+		if tombstone.Node == nil {
+			continue
+		}
+		m.nodeStateUpdate <- NodeStateUpdate{tombstone.Node, int(tombstone.State), GetId(node)}
+	}
+
+	log.Debugw("Pulled state", "node", node.Name, "samples", len(res.Samples), "nodes", len(res.Nodes), "tombstones", len(res.Tombstones))
+}
+
 func (m *Mesh) initClient(to *meshv1.Node) error {
 	nodeId := GetId(to)
 	log := m.logger.Named("client")
@@ -182,6 +426,10 @@ func (m *Mesh) initClient(to *meshv1.Node) error {
 		grpc_zap.ReplaceGrpcLoggerV2(log.Named("grpc").Desugar())
 	}
 
+	// the check and the creation are locked, requests to many nodes initialize clients in parallel
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, exists := m.clients[nodeId]; !exists {
 		var opts []grpc.DialOption
 
@@ -206,12 +454,10 @@ func (m *Mesh) initClient(to *meshv1.Node) error {
 
 		client := meshv1.NewMeshServiceClient(conn)
 
-		m.mu.Lock() // TODO has to be moved to the top?
 		m.clients[nodeId] = &MeshClient{
 			client: client,
 			conn:   conn,
 		}
-		m.mu.Unlock()
 	} else {
 		log.Debugw("Client already existed")
 	}
@@ -227,114 +473,34 @@ func (m *Mesh) timeoutInterceptor(
 	invoker grpc.UnaryInvoker,
 	opts ...grpc.CallOption,
 ) error {
-	ctx, close := context.WithTimeout(context.Background(), m.routineConfig.RequestTimeout)
-	defer close()
+	// keep a longer deadline set by the caller
+	if _, ok := ctx.Deadline(); !ok {
+		var close context.CancelFunc
+		ctx, close = context.WithTimeout(ctx, m.routineConfig.RequestTimeout)
+		defer close()
+	}
 	// Calls the invoker to execute RPC
 	err := invoker(ctx, method, req, reply, cc, opts...)
 	return err
 }
 
+// client returns the initialized client of a node
+func (m *Mesh) client(to *meshv1.Node) meshv1.MeshServiceClient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.clients[GetId(to)].client
+}
+
 func (m *Mesh) closeClient(to *meshv1.Node) error {
 	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	err := m.clients[GetId(to)].conn.Close()
 	if err != nil {
 		return err
 	}
 	// remove client
 	delete(m.clients, GetId(to))
-	m.mu.Unlock()
 	return nil
 }
-
-func (m *Mesh) Rtt() {
-	log := m.logger.Named("rtt")
-	log.Debugw("Starting RTT measurement")
-	var opts []grpc.DialOption
-	var rttStartH, rttStart, rttEnd time.Time
-
-	nodes := m.database.GetRandomNodeListByState(NodeOk, 1)
-	if nodes == nil {
-		log.Debugw("No Node suitable for RTT measurement")
-		return
-	}
-	// select random node for RTT measurement
-	node := nodes[0]
-	log.Debugw("Node selected", "node", node.Name)
-	// grpc logging
-	if m.setupConfig.DebugGrpc {
-		grpc_zap.ReplaceGrpcLoggerV2(log.Named("grpc").Desugar())
-	}
-
-	// TLS
-	tlsCredentials, err := h.LoadClientTLSCredentials(m.setupConfig.CaCertPath, m.setupConfig.CaCert)
-	if err != nil {
-		log.Debugw("Cannot load TLS credentials - starting insecure connection", "error", err.Error())
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(tlsCredentials))
-	}
-
-	// blocking
-	opts = append(opts, grpc.WithBlock())
-
-	// start RTT with TCP handshake
-	rttStartH = time.Now()
-	// dial
-	conn, err := grpc.Dial(node.Target, opts...)
-	defer conn.Close()
-	if err != nil {
-		log.Debugw("Dial error", "error", err)
-		return
-	}
-
-	client := meshv1.NewMeshServiceClient(conn)
-	if err != nil {
-		log.Debugw("Could not connect to client")
-		return
-	}
-
-	// start RTT without a TCP handshake
-	rttStart = time.Now()
-
-	// send request
-	_, err = client.Rtt(context.Background(), &emptypb.Empty{})
-	// end RTT
-	rttEnd = time.Now()
-
-	if err != nil {
-		log.Debugw("RTT failed")
-		return
-	}
-	log.Debugw("RTT succeeded")
-	// RTT with handshake
-	rttH := rttEnd.Sub(rttStartH)
-	// RTT without handshake
-	rtt := rttEnd.Sub(rttStart)
-
-	// save metrics
-	m.metrics.GetRtt().WithLabelValues(data.SampleName[data.RttTotal], node.Name).Observe(rttH.Seconds())
-	m.metrics.GetRtt().WithLabelValues(data.SampleName[data.RttRequest], node.Name).Observe(rtt.Seconds())
-
-	// save samples
-	m.database.SetSample(
-		&data.Sample{
-			From:  m.setupConfig.Name,
-			To:    node.Name,
-			Key:   data.RttTotal,
-			Value: strconv.FormatInt(rttH.Nanoseconds(), 10),
-			Ts:    time.Now().Unix(),
-		},
-	)
-
-	m.database.SetSample(
-		&data.Sample{
-			From:  m.setupConfig.Name,
-			To:    node.Name,
-			Key:   data.RttRequest,
-			Value: strconv.FormatInt(rtt.Nanoseconds(), 10),
-			Ts:    time.Now().Unix(),
-		},
-	)
-
-	return
-}
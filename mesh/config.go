@@ -22,10 +22,13 @@
 package mesh
 
 import (
+	"math"
 	"strconv"
 	"time"
 
+	"github.com/telekom/canary-bot/data"
 	h "github.com/telekom/canary-bot/helper"
+	"github.com/telekom/canary-bot/probe"
 
 	"go.uber.org/zap"
 )
@@ -42,6 +45,9 @@ type RoutineConfiguration struct {
 	PingInterval    time.Duration
 	PingRetryAmount int
 	PingRetryDelay  time.Duration
+	// Amount of nodes asked to ping a node indirectly,
+	// if a direct ping failed
+	PingReqAmount int
 
 	// Node discovery
 	BroadcastToAmount int
@@ -51,13 +57,69 @@ type RoutineConfiguration struct {
 	PushSampleToAmount    int
 	PushSampleRetryAmount int
 	PushSampleRetryDelay  time.Duration
+	// Interval to push all samples instead of the changed ones to a node
+	PushSampleFullSyncInterval time.Duration
+
+	// Pull missing samples & nodes (anti-entropy)
+	PullStateInterval time.Duration
 
 	// Clean nodes & samples
 	CleanupInterval time.Duration
 	CleanupMaxAge   time.Duration
+	// Time a removed node is gossiped as tombstone, peers will not re-add it; deleted afterwards
+	TombstoneTTL time.Duration
+
+	// Local history per sample series, limited by the time window and the amount of entries.
+	// A size of 0 derives the amount from the time window and the RTT interval, a negative size disables it.
+	SampleHistorySize   int
+	SampleHistoryMaxAge time.Duration
+
+	// Interval to write the snapshot of nodes & samples, if a snapshot path is set
+	SnapshotInterval time.Duration
+
+	// Re-contact the targets and dead nodes to heal partitions
+	ReconcileInterval   time.Duration
+	ReconcileDeadAmount int
 
 	// Sample: RTT
 	RttInterval time.Duration
+	// Clock offset of a node to warn about
+	ClockSkewThreshold time.Duration
+	// Sample: HTTP(S) targets
+	HttpInterval time.Duration
+	// Sample: packet loss & jitter of a request burst
+	LossInterval       time.Duration
+	LossBurstAmount    int
+	LossBurstSpacing   time.Duration
+	LossRequestTimeout time.Duration
+	// Sample: TCP connect to host:port targets
+	TcpInterval time.Duration
+	TcpTimeout  time.Duration
+	// Sample: DNS resolution of hostnames
+	DnsInterval time.Duration
+	DnsTimeout  time.Duration
+	// Sample: throughput to a random node in both directions
+	ThroughputInterval  time.Duration
+	ThroughputDuration  time.Duration
+	ThroughputChunkSize int
+	// Sample: gRPC health of services
+	GrpcHealthInterval time.Duration
+	GrpcHealthTimeout  time.Duration
+	// Sample: local commands
+	ExecInterval time.Duration
+	ExecTimeout  time.Duration
+	// Sample: TLS certificates & handshake of endpoints and peers
+	TlsInterval time.Duration
+	TlsTimeout  time.Duration
+
+	// Every healthy node is probed at least once per cycle by the probes opting in (e.g. RTT).
+	// Probes with an interval of at least the cycle are not affected.
+	// Set to 0 to probe a random node per interval.
+	ProbeCycle time.Duration
+	// Additional probes, scheduled besides the RTT measurement
+	Probes *probe.Registry
+	// Storage of nodes & samples, an in-memory database is created if nil
+	Store data.Store
 }
 
 // Configuration how the bot can connect to the mesh etc.
@@ -70,6 +132,36 @@ type SetupConfiguration struct {
 	JoinAddress   string
 	ListenAddress string
 	ListenPort    int64
+	// Labels of this node e.g. zone, region, cluster
+	Labels map[string]string
+
+	// HTTP(S) probe: external URLs and optional body regex
+	HttpTargets   []string
+	HttpBodyMatch string
+
+	// UDP echo port for the packet loss probe, 0 disables it
+	UdpEchoPort int64
+
+	// TCP connect probe: host:port targets
+	TcpTargets []string
+
+	// DNS probe: hostnames, explicit resolvers and expected answers per hostname
+	DnsTargets   []string
+	DnsResolvers []string
+	DnsExpect    map[string]string
+
+	// TLS probe: host:port endpoints, peers are added if mesh TLS is enabled
+	TlsTargets []string
+
+	// Enable the throughput probe
+	Throughput bool
+
+	// gRPC health probe: host:port targets with optional service name
+	GrpcHealthTargets []string
+
+	// Exec probe: commands by name and additional environment
+	ExecCommands map[string]string
+	ExecEnv      map[string]string
 
 	// API
 	ApiPort int64
@@ -87,9 +179,13 @@ type SetupConfiguration struct {
 	Tokens []string
 
 	// Clean nodes & samples
+	// Deprecated: removed nodes are always deleted after TombstoneTTL
 	CleanupNodes   bool
 	CleanupSamples bool
 
+	// File to persist nodes & samples, reloaded on start; empty disables it
+	SnapshotPath string
+
 	//Logging
 	Debug     bool
 	DebugGrpc bool
@@ -98,21 +194,69 @@ type SetupConfiguration struct {
 // Use standard configuration parameters for your production
 func StandardProductionRoutineConfig() *RoutineConfiguration {
 	return &RoutineConfiguration{
-		RequestTimeout:        time.Second * 3,
-		JoinInterval:          time.Second * 3,
-		PingInterval:          time.Second * 10,
-		PingRetryAmount:       3,
-		PingRetryDelay:        time.Second * 5,
-		BroadcastToAmount:     2,
-		PushSampleInterval:    time.Second * 5,
-		PushSampleToAmount:    2,
-		PushSampleRetryAmount: 2,
-		PushSampleRetryDelay:  time.Second * 10,
-		CleanupInterval:       time.Minute,
-		CleanupMaxAge:         time.Hour * 24,
-
-		RttInterval: time.Second * 3,
+		RequestTimeout:             time.Second * 3,
+		JoinInterval:               time.Second * 3,
+		PingInterval:               time.Second * 10,
+		PingRetryAmount:            3,
+		PingRetryDelay:             time.Second * 5,
+		PingReqAmount:              3,
+		BroadcastToAmount:          2,
+		PushSampleInterval:         time.Second * 5,
+		PushSampleToAmount:         2,
+		PushSampleRetryAmount:      2,
+		PushSampleRetryDelay:       time.Second * 10,
+		PushSampleFullSyncInterval: time.Minute * 5,
+		PullStateInterval:          time.Minute,
+		CleanupInterval:            time.Minute,
+		CleanupMaxAge:              time.Hour * 24,
+		TombstoneTTL:               time.Hour,
+		SampleHistorySize:          0,
+		SampleHistoryMaxAge:        time.Hour,
+		SnapshotInterval:           time.Minute,
+		ReconcileInterval:          time.Minute,
+		ReconcileDeadAmount:        2,
+
+		RttInterval:        time.Second * 3,
+		ClockSkewThreshold: time.Second,
+		HttpInterval:       time.Second * 10,
+
+		LossInterval:       time.Second * 30,
+		LossBurstAmount:    10,
+		LossBurstSpacing:   time.Millisecond * 100,
+		LossRequestTimeout: time.Second,
+
+		TcpInterval: time.Second * 10,
+		TcpTimeout:  time.Second * 3,
+
+		DnsInterval: time.Second * 30,
+		DnsTimeout:  time.Second * 3,
+
+		TlsInterval: time.Minute,
+		TlsTimeout:  time.Second * 3,
+
+		ThroughputInterval:  time.Minute * 10,
+		ThroughputDuration:  time.Second * 2,
+		ThroughputChunkSize: 64 * 1024,
+
+		GrpcHealthInterval: time.Second * 10,
+		GrpcHealthTimeout:  time.Second * 3,
+
+		ExecInterval: time.Second * 30,
+		ExecTimeout:  time.Second * 10,
+
+		// probe a random node per interval, set a cycle to opt in
+		ProbeCycle: 0,
+		Probes:     probe.NewRegistry(),
+	}
+}
+
+// historySize returns the amount of history entries per sample series.
+// Without a set size the time window is covered at the RTT interval, the most frequent probe.
+func (routineConfig *RoutineConfiguration) historySize() int {
+	if routineConfig.SampleHistorySize != 0 || routineConfig.SampleHistoryMaxAge <= 0 || routineConfig.RttInterval <= 0 {
+		return routineConfig.SampleHistorySize
 	}
+	return int(math.Ceil(float64(routineConfig.SampleHistoryMaxAge) / float64(routineConfig.RttInterval)))
 }
 
 // Default setter method
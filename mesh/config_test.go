@@ -0,0 +1,49 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mesh
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_historySize(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *RoutineConfiguration
+		expected int
+	}{
+		{name: "derived from the default window", config: StandardProductionRoutineConfig(), expected: 1200},
+		{name: "derived from the window", config: &RoutineConfiguration{SampleHistoryMaxAge: 10 * time.Minute, RttInterval: 7 * time.Second}, expected: 86},
+		{name: "set size", config: &RoutineConfiguration{SampleHistorySize: 50, SampleHistoryMaxAge: time.Hour, RttInterval: time.Second}, expected: 50},
+		{name: "disabled", config: &RoutineConfiguration{SampleHistorySize: -1, SampleHistoryMaxAge: time.Hour, RttInterval: time.Second}, expected: -1},
+		{name: "without window", config: &RoutineConfiguration{RttInterval: time.Second}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.config.historySize(); result != tt.expected {
+				t.Errorf("result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+}
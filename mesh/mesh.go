@@ -22,15 +22,22 @@
 package mesh
 
 import (
+	"errors"
 	"log"
+	"os"
+	"os/signal"
+	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/telekom/canary-bot/api"
 	"github.com/telekom/canary-bot/data"
 	h "github.com/telekom/canary-bot/helper"
 	"github.com/telekom/canary-bot/metric"
+	"github.com/telekom/canary-bot/probe"
 	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
 
 	"go.uber.org/zap"
@@ -39,7 +46,7 @@ import (
 // Mesh is the internal mesh representation
 type Mesh struct {
 	// Mesh in-memory datastore
-	database data.Database
+	database data.Store
 	// Metrics for bot
 	metrics metric.Metrics
 	// Global zap logger
@@ -48,21 +55,37 @@ type Mesh struct {
 	routineConfig *RoutineConfiguration
 	// Configuration how the bot can connect to the mesh etc.
 	setupConfig *SetupConfiguration
+	// Sorted keys of the node labels used in metrics
+	labelKeys []string
 
 	// GRPC client store
 	clients map[uint32]*MeshClient
 	mu      sync.Mutex
 
+	// Acknowledged sample pushes per node
+	sampleWatermarks map[uint32]*sampleWatermark
+	watermarkMu      sync.Mutex
+
 	// Channel if a new node is discovered in the mesh
 	newNodeDiscovered chan NodeDiscovered
+	// Channel if a state of a node is gossiped in the mesh
+	nodeStateUpdate chan NodeStateUpdate
+
+	// Incarnation of this node, increased to refute suspicions
+	incarnation atomic.Uint64
 
 	// timerRoutine main functionality timers
 	pingTicker       *time.Ticker
 	pushSampleTicker *time.Ticker
+	pullStateTicker  *time.Ticker
 	cleanupTicker    *time.Ticker
+	reconcileTicker  *time.Ticker
+	snapshotTicker   *time.Ticker
 
-	// timerRoutine sample measurement timers
-	rttTicker *time.Ticker
+	// Probes measuring the samples
+	probes []probe.Probe
+	// timerRoutine sample measurement timers, one per probe
+	probeTickers []*time.Ticker
 
 	// Channels to quit and re-enter mesh joinRoutine
 	quitJoinRoutine    chan bool
@@ -76,6 +99,13 @@ type NodeDiscovered struct {
 	From    uint32 // TODO change to name
 }
 
+// NodeStateUpdate represents a gossiped state of a node in the mesh
+type NodeStateUpdate struct {
+	Node  *meshv1.Node
+	State int
+	From  uint32
+}
+
 // CreateCanaryMesh creates a canary bot & mesh with the desired configuration
 // Use a pre-defined routineConfig with e.g. StandardProductionRoutineConfig()
 // and define your mesh setup configuration
@@ -104,14 +134,44 @@ func CreateCanaryMesh(routineConfig *RoutineConfiguration, setupConfig *SetupCon
 	// Get info from configuration combination
 	setupConfig.checkDefaults(logger)
 
-	// prepare the in-memory database
-	database, err := data.NewMemDB(logger.Named("database"))
-	if err != nil {
-		logger.Fatalf("Could not create Memory Database (MemDB) - Error: %+v", err)
+	// prepare the in-memory database, if no store is set
+	var err error
+	database := routineConfig.Store
+	if database == nil {
+		var memDB *data.Database
+		memDB, err = data.NewMemDB(logger.Named("database"))
+		if err != nil {
+			logger.Fatalf("Could not create Memory Database (MemDB) - Error: %+v", err)
+		}
+		memDB.SetHistoryLimit(routineConfig.historySize(), routineConfig.SampleHistoryMaxAge)
+		database = memDB
 	}
 
-	// init metrics
-	metrics := metric.InitMetrics()
+	// reload the known nodes & samples of the last run
+	if setupConfig.SnapshotPath != "" {
+		nodes, samples, err := data.LoadSnapshot(database, setupConfig.SnapshotPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			logger.Infow("No snapshot found, starting with an empty database", "path", setupConfig.SnapshotPath)
+		case err != nil:
+			logger.Warnw("Could not load snapshot, starting with an empty database", "path", setupConfig.SnapshotPath, "error", err)
+		default:
+			logger.Infow("Loaded snapshot", "path", setupConfig.SnapshotPath, "nodes", nodes, "samples", samples)
+		}
+	}
+
+	// init metrics, node labels will be added to the metrics
+	labelKeys := make([]string, 0, len(setupConfig.Labels))
+	for key := range setupConfig.Labels {
+		labelKeys = append(labelKeys, key)
+	}
+	sort.Strings(labelKeys)
+	metrics := metric.InitMetrics(labelKeys...)
+
+	// probes, the RTT measurement is always scheduled
+	if routineConfig.Probes == nil {
+		routineConfig.Probes = probe.NewRegistry()
+	}
 
 	m := &Mesh{
 		database:           database,
@@ -119,12 +179,77 @@ func CreateCanaryMesh(routineConfig *RoutineConfiguration, setupConfig *SetupCon
 		logger:             logger,
 		routineConfig:      routineConfig,
 		setupConfig:        setupConfig,
+		labelKeys:          labelKeys,
 		clients:            map[uint32]*MeshClient{},
+		sampleWatermarks:   map[uint32]*sampleWatermark{},
 		newNodeDiscovered:  make(chan NodeDiscovered),
+		nodeStateUpdate:    make(chan NodeStateUpdate),
 		quitJoinRoutine:    make(chan bool, 1),
 		restartJoinRoutine: make(chan bool, 1),
 		joinRoutineDone:    false,
 	}
+	if err = routineConfig.Probes.Register(&rttProbe{m: m}); err != nil {
+		logger.Fatalf("Could not register RTT probe - Error: %+v", err)
+	}
+	if err = routineConfig.Probes.Register(&lossProbe{m: m}); err != nil {
+		logger.Fatalf("Could not register loss probe - Error: %+v", err)
+	}
+	if setupConfig.UdpEchoPort > 0 {
+		udpProbe := probe.NewUDPProbe(setupConfig.UdpEchoPort, routineConfig.LossBurstAmount, routineConfig.LossBurstSpacing, routineConfig.LossInterval, routineConfig.LossRequestTimeout)
+		if err = routineConfig.Probes.Register(udpProbe); err != nil {
+			logger.Fatalf("Could not register UDP loss probe - Error: %+v", err)
+		}
+	}
+	if len(setupConfig.HttpTargets) > 0 {
+		httpProbe, err := probe.NewHTTPProbe(setupConfig.HttpTargets, setupConfig.HttpBodyMatch, routineConfig.HttpInterval, routineConfig.RequestTimeout)
+		if err != nil {
+			logger.Fatalf("Could not create HTTP probe - Error: %+v", err)
+		}
+		if err = routineConfig.Probes.Register(httpProbe); err != nil {
+			logger.Fatalf("Could not register HTTP probe - Error: %+v", err)
+		}
+	}
+	if len(setupConfig.TcpTargets) > 0 {
+		tcpProbe, err := probe.NewTCPProbe(setupConfig.TcpTargets, routineConfig.TcpInterval, routineConfig.TcpTimeout)
+		if err != nil {
+			logger.Fatalf("Could not create TCP probe - Error: %+v", err)
+		}
+		if err = routineConfig.Probes.Register(tcpProbe); err != nil {
+			logger.Fatalf("Could not register TCP probe - Error: %+v", err)
+		}
+	}
+	if len(setupConfig.DnsTargets) > 0 {
+		dnsProbe, err := probe.NewDNSProbe(setupConfig.DnsTargets, setupConfig.DnsResolvers, setupConfig.DnsExpect, routineConfig.DnsInterval, routineConfig.DnsTimeout)
+		if err != nil {
+			logger.Fatalf("Could not create DNS probe - Error: %+v", err)
+		}
+		if err = routineConfig.Probes.Register(dnsProbe); err != nil {
+			logger.Fatalf("Could not register DNS probe - Error: %+v", err)
+		}
+	}
+	if setupConfig.Throughput {
+		if err = routineConfig.Probes.Register(&throughputProbe{m: m}); err != nil {
+			logger.Fatalf("Could not register throughput probe - Error: %+v", err)
+		}
+	}
+	if len(setupConfig.GrpcHealthTargets) > 0 {
+		if err = m.registerGrpcHealthProbe(); err != nil {
+			logger.Fatalf("Could not register gRPC health probe - Error: %+v", err)
+		}
+	}
+	if len(setupConfig.ExecCommands) > 0 {
+		execProbe, err := probe.NewExecProbe(setupConfig.ExecCommands, setupConfig.ExecEnv, routineConfig.ExecInterval, routineConfig.ExecTimeout)
+		if err != nil {
+			logger.Fatalf("Could not create exec probe - Error: %+v", err)
+		}
+		if err = routineConfig.Probes.Register(execProbe); err != nil {
+			logger.Fatalf("Could not register exec probe - Error: %+v", err)
+		}
+	}
+	if err = m.registerTlsProbe(); err != nil {
+		logger.Fatalf("Could not register TLS probe - Error: %+v", err)
+	}
+	m.probes = routineConfig.Probes.Probes()
 	logger.Info("Starting mesh")
 
 	// start mesh server
@@ -137,14 +262,30 @@ func CreateCanaryMesh(routineConfig *RoutineConfiguration, setupConfig *SetupCon
 		}
 	}()
 
+	// start UDP echo listener for the packet loss probe
+	if setupConfig.UdpEchoPort > 0 {
+		go func() {
+			logger.Info("Starting UDP echo listener")
+			err := m.StartUdpEcho()
+			if err != nil {
+				logger.Debugf("UDP echo error: %+v", err)
+				logger.Fatal("Could not start UDP echo listener")
+			}
+		}()
+	}
+
 	// start the main mesh functionality
 	logger.Infow("Starting mesh routines")
 	go m.channelRoutines()
 	go m.timerRoutines()
 
+	// leave the mesh on shutdown
+	go m.leaveOnSignal()
+
 	// start API
 	apiConfig := &api.Configuration{
 		NodeName:       setupConfig.Name,
+		NodeLabels:     setupConfig.Labels,
 		Address:        setupConfig.ListenAddress,
 		Port:           setupConfig.ApiPort,
 		Tokens:         setupConfig.Tokens,
@@ -176,13 +317,29 @@ func (m *Mesh) timerRoutines() {
 	// Timer to send samples to node
 	m.pushSampleTicker = time.NewTicker(m.routineConfig.PushSampleInterval)
 	m.pushSampleTicker.Stop()
+	// Timer to pull missing samples and nodes from a node
+	m.pullStateTicker = time.NewTicker(m.routineConfig.PullStateInterval)
+	m.pullStateTicker.Stop()
 	// Timer to clean samples from removed nodes
 	m.cleanupTicker = time.NewTicker(m.routineConfig.CleanupInterval)
 	m.cleanupTicker.Stop()
+	// Timer to re-contact targets and dead nodes
+	m.reconcileTicker = time.NewTicker(m.routineConfig.ReconcileInterval)
+	m.reconcileTicker.Stop()
+
+	// snapshots are written independent of the join state
+	m.snapshotTicker = time.NewTicker(m.routineConfig.SnapshotInterval)
+	if m.setupConfig.SnapshotPath == "" {
+		m.snapshotTicker.Stop()
+	}
 
-	// Sample measurement: RTT
-	m.rttTicker = time.NewTicker(m.routineConfig.RttInterval)
-	m.rttTicker.Stop()
+	// Sample measurement: one ticker per probe
+	m.probeTickers = make([]*time.Ticker, len(m.probes))
+	for i, p := range m.probes {
+		m.probeTickers[i] = time.NewTicker(p.Interval())
+		m.probeTickers[i].Stop()
+		go m.probeRoutine(p, m.probeTickers[i])
+	}
 
 	for {
 		select {
@@ -191,7 +348,7 @@ func (m *Mesh) timerRoutines() {
 			log := m.logger.Named("join-routine")
 			// join (future) mesh
 			log.Infow("Waiting for a node to join a mesh...")
-			connected, isNameUniqueInMesh := m.Join(m.setupConfig.Targets)
+			connected, isNameUniqueInMesh := m.Join(m.joinTargets())
 			if !isNameUniqueInMesh {
 				log.Fatal("The name is not unique in the mesh, please choose another one.")
 			}
@@ -233,13 +390,25 @@ func (m *Mesh) timerRoutines() {
 				go m.retryPushSample(node.Convert())
 			}
 
+		case <-m.pullStateTicker.C:
+			log := m.logger.Named("pull-routine")
+			log.Debugw("Starting pull state routine to a random node")
+
+			// get a random healthy node
+			nodes := m.database.GetRandomNodeListByState(NodeOk, 1)
+			if len(nodes) == 0 {
+				log.Debugw("No node connected or all nodes in timeout")
+				break
+			}
+
+			go m.PullState(nodes[0].Convert())
+
 		case <-m.cleanupTicker.C:
-			// check if the node is timed-out and over maxAge
-			if m.setupConfig.CleanupNodes {
-				for _, node := range m.database.GetNodeListByState(NodeDead) {
-					if time.Unix(node.StateChangeTs, 0).Before(time.Now().Add(-1 * m.routineConfig.CleanupMaxAge)) {
-						m.logger.Infow("Delete old node", "node", node.Name, "maxAge", m.routineConfig.CleanupMaxAge.String())
-					}
+			// check if the node is removed and its tombstone is not gossiped anymore
+			for _, node := range m.database.GetNodeList() {
+				if isRemoved(node) && !isTombstoneActive(node, m.routineConfig.TombstoneTTL) {
+					m.logger.Infow("Delete old node", "node", node.Name, "tombstoneTTL", m.routineConfig.TombstoneTTL.String())
+					m.purgeNode(node)
 				}
 			}
 
@@ -253,9 +422,20 @@ func (m *Mesh) timerRoutines() {
 				}
 			}
 
-		case <-m.rttTicker.C:
-			// measure round-trip-time samples
-			go m.Rtt()
+		case <-m.reconcileTicker.C:
+			log := m.logger.Named("reconcile-routine")
+			log.Debugw("Starting reconciliation with targets and random dead nodes", "amount", m.routineConfig.ReconcileDeadAmount)
+
+			// configured targets and dead nodes could be in another part of a split mesh
+			targets := append([]string{}, m.setupConfig.Targets...)
+			for _, node := range m.database.GetRandomNodeListByState(NodeDead, m.routineConfig.ReconcileDeadAmount) {
+				targets = append(targets, node.Target)
+			}
+
+			go m.Reconcile(targets)
+
+		case <-m.snapshotTicker.C:
+			m.saveSnapshot()
 
 		case <-m.restartJoinRoutine:
 			// stop ticker and re-enter joinRoutine
@@ -264,8 +444,12 @@ func (m *Mesh) timerRoutines() {
 
 			m.pingTicker.Stop()
 			m.pushSampleTicker.Stop()
+			m.pullStateTicker.Stop()
 			m.cleanupTicker.Stop()
-			m.rttTicker.Stop()
+			m.reconcileTicker.Stop()
+			for _, ticker := range m.probeTickers {
+				ticker.Stop()
+			}
 			m.logger.Debug("Start joinRoutine again, stopping all timer routines")
 		case <-m.quitJoinRoutine:
 			joinTicker.Stop()
@@ -273,8 +457,12 @@ func (m *Mesh) timerRoutines() {
 			// starting ticker after joinRoutine
 			m.pingTicker.Reset(m.routineConfig.PingInterval)
 			m.pushSampleTicker.Reset(m.routineConfig.PushSampleInterval)
+			m.pullStateTicker.Reset(m.routineConfig.PullStateInterval)
 			m.cleanupTicker.Reset(m.routineConfig.CleanupInterval)
-			m.rttTicker.Reset(m.routineConfig.RttInterval)
+			m.reconcileTicker.Reset(m.routineConfig.ReconcileInterval)
+			for i, ticker := range m.probeTickers {
+				ticker.Reset(m.probes[i].Interval())
+			}
 			m.logger.Info("Starting pings")
 			m.logger.Debug("Stop joinRoutine, starting all timer routines")
 		}
@@ -285,6 +473,8 @@ func (m *Mesh) timerRoutines() {
 // Events:
 //
 // - nodeDiscovered: A new node is discovered in the mesh
+//
+// - nodeStateUpdate: A state of a node is gossiped in the mesh
 func (m *Mesh) channelRoutines() {
 	for {
 		select {
@@ -294,9 +484,19 @@ func (m *Mesh) channelRoutines() {
 			if !m.joinRoutineDone {
 				m.quitJoinRoutine <- true
 			}
-			if m.database.GetNodeByName(nodeDiscovered.NewNode.Name).Id != 0 {
+			known := m.database.GetNodeByName(nodeDiscovered.NewNode.Name)
+			// a removed node is just re-added by its own join request or a newer incarnation,
+			// not by outdated discoveries of other nodes
+			if isRemoved(known) && nodeDiscovered.From != GetId(nodeDiscovered.NewNode) &&
+				nodeDiscovered.NewNode.Incarnation <= known.Incarnation {
+				logger.Debugw("Ignoring discovery of removed node", "node", known.Name, "state", known.State)
+				break
+			}
+			if known.Id != 0 {
 				logger.Info("Node is rejoining node")
 				m.database.SetNode(data.Convert(nodeDiscovered.NewNode, NodeOk))
+				// the node could have lost its samples
+				m.resetSampleWatermark(GetId(nodeDiscovered.NewNode))
 				break
 			}
 
@@ -316,8 +516,133 @@ func (m *Mesh) channelRoutines() {
 			}
 
 			m.database.SetNode(data.Convert(nodeDiscovered.NewNode, NodeOk))
+
+		case update := <-m.nodeStateUpdate:
+			logger := m.logger.Named("state-routine")
+
+			// this node is suspected or declared dead; refute it
+			if update.Node.Name == m.setupConfig.Name {
+				if update.State != NodeOk && update.Node.Incarnation >= m.incarnation.Load() {
+					m.incarnation.Store(update.Node.Incarnation + 1)
+					logger.Infow("Refuting suspicion", "state", update.State, "incarnation", m.incarnation.Load())
+					m.gossipNodeState(m.ownNode(), NodeOk, update.From)
+					// the sender could be the suspecting node
+					if from := m.database.GetNode(update.From); from.Id != 0 {
+						go m.NodeStateUpdate(from.Convert(), m.ownNode(), NodeOk)
+					}
+				}
+				break
+			}
+
+			// ignore outdated or already known states
+			if !overridesNodeState(update.State, update.Node.Incarnation, m.database.GetNodeByName(update.Node.Name)) {
+				break
+			}
+
+			logger.Infow("Node state changed", "node", update.Node.Name, "state", update.State, "incarnation", update.Node.Incarnation)
+			switch update.State {
+			case NodeDead:
+				// keep the node as tombstone to not re-add it by outdated gossip
+				m.metrics.GetNodeRemoved().WithLabelValues("dead", update.Node.Name).Inc()
+				m.database.SetNode(data.Convert(update.Node, NodeDead))
+			case NodeLeft:
+				// keep the node as tombstone to not re-add it by outdated gossip
+				m.metrics.GetNodeRemoved().WithLabelValues("left", update.Node.Name).Inc()
+				m.database.SetNode(data.Convert(update.Node, NodeLeft))
+			default:
+				m.database.SetNode(data.Convert(update.Node, update.State))
+			}
+
+			// the last node left the mesh
+			if update.State != NodeOk && m.isAlone() {
+				select {
+				case m.restartJoinRoutine <- true:
+				default:
+				}
+				break
+			}
+
+			// spread the news
+			m.gossipNodeState(update.Node, update.State, update.From)
+		}
+	}
+}
+
+// gossipNodeState sends the state of a node to a configured amount of random nodes.
+// The node itself and the without nodes will be excluded,
+// just a suspected node is told about the suspicion to refute it.
+func (m *Mesh) gossipNodeState(node *meshv1.Node, state int, without ...uint32) {
+	logger := m.logger.Named("state-routine")
+
+	if state == NodeTimeout {
+		logger.Debugw("Sending suspicion to suspected node", "node", node.Name)
+		go m.NodeStateUpdate(node, node, state)
+	}
+
+	nodes := m.database.GetRandomNodeListByState(NodeOk, m.routineConfig.BroadcastToAmount, append(without, GetId(node))...)
+	if len(nodes) == 0 {
+		logger.Debug("No nodes to gossip the node state to")
+		return
+	}
+
+	for _, to := range nodes {
+		logger.Debugw("Sending node state", "to", to.Name, "node", node.Name, "state", state)
+		go m.NodeStateUpdate(to.Convert(), node, state)
+	}
+}
+
+// leaveOnSignal waits for a SIGTERM or SIGINT,
+// tells the mesh that this node is leaving and exits.
+func (m *Mesh) leaveOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	sig := <-signals
+	m.logger.Infow("Shutting down - leaving mesh", "signal", sig.String())
+	m.Leave()
+	if m.setupConfig.SnapshotPath != "" {
+		m.saveSnapshot()
+	}
+	_ = m.logger.Sync()
+	os.Exit(0)
+}
+
+// joinTargets returns the configured targets followed by the targets of the known nodes,
+// a restarted node can rejoin the mesh even if the configured targets are down.
+func (m *Mesh) joinTargets() []string {
+	targets := append([]string{}, m.setupConfig.Targets...)
+	seen := map[string]bool{m.setupConfig.JoinAddress: true}
+	for _, target := range targets {
+		seen[target] = true
+	}
+	for _, node := range m.database.GetNodeList() {
+		if node.State == NodeLeft || seen[node.Target] {
+			continue
+		}
+		seen[node.Target] = true
+		targets = append(targets, node.Target)
+	}
+	return targets
+}
+
+// saveSnapshot writes the nodes & samples to the snapshot file
+func (m *Mesh) saveSnapshot() {
+	if err := data.SaveSnapshot(m.database, m.setupConfig.SnapshotPath); err != nil {
+		m.logger.Warnw("Could not write snapshot", "path", m.setupConfig.SnapshotPath, "error", err)
+		return
+	}
+	m.logger.Debugw("Snapshot written", "path", m.setupConfig.SnapshotPath)
+}
+
+// purgeNode deletes a removed node and the samples from and to the node
+func (m *Mesh) purgeNode(node *data.Node) {
+	for _, sample := range m.database.GetSampleList() {
+		if sample.From == node.Name || sample.To == node.Name {
+			m.database.DeleteSample(sample.Id)
 		}
 	}
+	m.database.DeleteNode(node.Id)
+	m.resetSampleWatermark(node.Id)
 }
 
 // retryPing Will call the ping method with set retry configuration.
@@ -326,6 +651,8 @@ func (m *Mesh) retryPing(node *meshv1.Node) {
 	logger := m.logger.Named("ping-routine")
 	logger.Debugw("Retry routine started", "node", node.Name)
 
+	suspected := false
+
 	// start retry ping logic
 	for r := 1; r <= m.routineConfig.PingRetryAmount; r++ {
 		// Ping the node
@@ -339,14 +666,41 @@ func (m *Mesh) retryPing(node *meshv1.Node) {
 		}
 
 		// Ping failed
-		logger.Infow("Ping failed", "node", node.Name, "timeout", m.routineConfig.RequestTimeout.String(), "retry in", m.routineConfig.PingRetryDelay.String(), "attempt", r)
-		m.database.SetNode(data.Convert(node, NodeTimeout))
+		logger.Infow("Ping failed", "node", node.Name, "timeout", m.routineConfig.RequestTimeout.String(), "attempt", r)
 		m.database.SetSampleNaN(GetSampleId(&meshv1.Sample{From: m.setupConfig.Name, To: node.Name, Key: data.RttRequest}))
 		m.database.SetSampleNaN(GetSampleId(&meshv1.Sample{From: m.setupConfig.Name, To: node.Name, Key: data.RttTotal}))
 
+		// Ask other nodes to ping the node; just the link to the node could be broken
+		if m.indirectPing(node) {
+			m.database.SetNode(data.Convert(node, NodeOk))
+			logger.Infow("Indirect ping ok", "node", node.Name, "attempt", r)
+			return
+		}
+
+		logger.Infow("Indirect ping failed", "node", node.Name, "retry in", m.routineConfig.PingRetryDelay.String(), "attempt", r)
+		m.database.SetNode(data.Convert(node, NodeTimeout))
+
+		// Let the mesh know about the suspicion, the node can refute it
+		if !suspected {
+			suspected = true
+			m.gossipNodeState(node, NodeTimeout)
+		}
+
 		if r != m.routineConfig.PingRetryAmount {
 			// Retry delay
 			time.Sleep(m.routineConfig.PingRetryDelay)
+
+			// Suspicion refuted by the node in the meantime
+			known := m.database.GetNode(GetId(node))
+			if known.State == NodeOk && known.Incarnation > node.Incarnation {
+				logger.Infow("Node refuted suspicion", "node", node.Name, "incarnation", known.Incarnation)
+				return
+			}
+			// Node left the mesh in the meantime
+			if known.State == NodeLeft {
+				logger.Infow("Node left the mesh", "node", node.Name)
+				return
+			}
 		} else {
 			m.database.SetNode(data.Convert(node, NodeDead))
 		}
@@ -355,14 +709,49 @@ func (m *Mesh) retryPing(node *meshv1.Node) {
 	// Retry limit reached
 	logger.Infow("Retry limit reached", "node", node.Name, "limit", m.routineConfig.PingRetryAmount)
 	logger.Warnw("Removing node from mesh", "node", node.Name)
-	m.database.DeleteNode(GetId(node))
+	m.metrics.GetNodeRemoved().WithLabelValues("dead", node.Name).Inc()
+	// the node is kept as tombstone until the cleanup
+	m.gossipNodeState(node, NodeDead)
 
 	// Check if node was the last node in mesh
-	if len(m.database.GetNodeList()) == 0 {
+	if m.isAlone() {
 		m.restartJoinRoutine <- true
 	}
 }
 
+// isAlone checks if there is no node left in the mesh, except nodes which left the mesh
+func (m *Mesh) isAlone() bool {
+	return len(m.database.GetNodeListByState(NodeOk)) == 0 &&
+		len(m.database.GetNodeListByState(NodeTimeout)) == 0
+}
+
+// indirectPing asks a configured amount of healthy nodes to ping the given node.
+// Returns true if at least one of the nodes could reach the node.
+func (m *Mesh) indirectPing(node *meshv1.Node) bool {
+	logger := m.logger.Named("ping-routine")
+
+	nodes := m.database.GetRandomNodeListByState(NodeOk, m.routineConfig.PingReqAmount, GetId(node))
+	if len(nodes) == 0 {
+		logger.Debugw("No node available for an indirect ping", "node", node.Name)
+		return false
+	}
+
+	// ask all chosen nodes in parallel
+	acks := make(chan bool, len(nodes))
+	for _, via := range nodes {
+		go func(via *meshv1.Node) {
+			acks <- m.pingReq(via, node) == nil
+		}(via.Convert())
+	}
+
+	for range nodes {
+		if <-acks {
+			return true
+		}
+	}
+	return false
+}
+
 // retryPushSample will call the pushSample method with set retry configuration.
 // Database nodes and samples will be updated.
 func (m *Mesh) retryPushSample(node *meshv1.Node) {
@@ -391,6 +780,29 @@ func (m *Mesh) retryPushSample(node *meshv1.Node) {
 	}
 }
 
+// ownNode returns this node as mesh node
+func (m *Mesh) ownNode() *meshv1.Node {
+	return &meshv1.Node{
+		Name:        m.setupConfig.Name,
+		Target:      m.setupConfig.JoinAddress,
+		Incarnation: m.incarnation.Load(),
+		Labels:      m.setupConfig.Labels,
+	}
+}
+
+// sampleLabelValues returns the metric label values for a sample to a target.
+// The order of the values matches the labels created by metric.InitMetrics.
+func (m *Mesh) sampleLabelValues(sampleKey int64, to string, toLabels map[string]string) []string {
+	values := []string{data.SampleName[sampleKey], to}
+	for _, key := range m.labelKeys {
+		values = append(values, m.setupConfig.Labels[key])
+	}
+	for _, key := range m.labelKeys {
+		values = append(values, toLabels[key])
+	}
+	return values
+}
+
 // GetId returns the hashed ID of a node
 func GetId(n *meshv1.Node) uint32 {
 	id, err := h.Hash(n.Target)
@@ -0,0 +1,350 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mesh
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
+	"go.uber.org/zap"
+)
+
+func Test_indirectPing(t *testing.T) {
+	// the suspected node is known by the helping nodes
+	known, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	known.SetNode(data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081"}, NodeTimeout))
+	failing := &MeshServer{log: zap.NewNop().Sugar(), data: known, ping: func(node *meshv1.Node) error { return errors.New("unreachable") }}
+	succeeding := &MeshServer{log: zap.NewNop().Sugar(), data: known, ping: func(node *meshv1.Node) error { return nil }}
+
+	tests := []struct {
+		name     string
+		servers  []*MeshServer
+		expected bool
+	}{
+		{name: "No node available", servers: []*MeshServer{}, expected: false},
+		{name: "All acks failed", servers: []*MeshServer{failing, failing}, expected: false},
+		{name: "One ack is enough", servers: []*MeshServer{failing, succeeding, failing}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, err := data.NewMemDB(zap.NewNop().Sugar())
+			if err != nil {
+				t.Fatalf("could not create db: %v", err)
+			}
+			for i, s := range tt.servers {
+				database.SetNode(data.Convert(&meshv1.Node{Name: "via" + strconv.Itoa(i), Target: startTestServer(t, s)}, NodeOk))
+			}
+
+			m := &Mesh{
+				database:      database,
+				logger:        zap.NewNop().Sugar(),
+				setupConfig:   &SetupConfiguration{Name: "owl"},
+				routineConfig: &RoutineConfiguration{RequestTimeout: time.Second, PingReqAmount: len(tt.servers)},
+				clients:       map[uint32]*MeshClient{},
+			}
+
+			if result := m.indirectPing(&meshv1.Node{Name: "swan", Target: "swan:8081"}); result != tt.expected {
+				t.Errorf("result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_refuteSuspicion(t *testing.T) {
+	received := make(chan NodeStateUpdate, 4)
+	target := startTestServer(t, &MeshServer{log: zap.NewNop().Sugar(), nodeStateUpdate: received})
+
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	swan := &meshv1.Node{Name: "swan", Target: target}
+	database.SetNode(data.Convert(swan, NodeOk))
+
+	m := &Mesh{
+		database:        database,
+		logger:          zap.NewNop().Sugar(),
+		setupConfig:     &SetupConfiguration{Name: "owl", JoinAddress: "owl:8081"},
+		routineConfig:   &RoutineConfiguration{RequestTimeout: time.Second, BroadcastToAmount: 2},
+		clients:         map[uint32]*MeshClient{},
+		nodeStateUpdate: make(chan NodeStateUpdate),
+	}
+	go m.channelRoutines()
+
+	// swan suspects this node
+	m.nodeStateUpdate <- NodeStateUpdate{Node: m.ownNode(), State: NodeTimeout, From: GetId(swan)}
+
+	select {
+	case update := <-received:
+		if update.Node.Name != "owl" || update.State != NodeOk || update.Node.Incarnation != 1 {
+			t.Errorf("the refutation is not as expected: %+v", update)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the suspicion was not refuted")
+	}
+	if incarnation := m.incarnation.Load(); incarnation != 1 {
+		t.Errorf("the incarnation (%v) is not as expected: 1", incarnation)
+	}
+}
+
+func Test_gossipSuspicion(t *testing.T) {
+	received := make(chan NodeStateUpdate, 1)
+	suspect := &meshv1.Node{Name: "swan", Target: startTestServer(t, &MeshServer{log: zap.NewNop().Sugar(), nodeStateUpdate: received})}
+
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(suspect, NodeTimeout))
+
+	m := &Mesh{
+		database:      database,
+		logger:        zap.NewNop().Sugar(),
+		setupConfig:   &SetupConfiguration{Name: "owl", JoinAddress: "owl:8081"},
+		routineConfig: &RoutineConfiguration{RequestTimeout: time.Second, BroadcastToAmount: 2},
+		clients:       map[uint32]*MeshClient{},
+	}
+	m.gossipNodeState(suspect, NodeTimeout)
+
+	// the suspected node is told about the suspicion
+	select {
+	case update := <-received:
+		if update.Node.Name != "swan" || update.State != NodeTimeout {
+			t.Errorf("the suspicion is not as expected: %+v", update)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the suspicion was not sent to the suspected node")
+	}
+}
+
+func Test_Leave(t *testing.T) {
+	received := make(chan NodeStateUpdate, 4)
+
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		target := startTestServer(t, &MeshServer{log: zap.NewNop().Sugar(), nodeStateUpdate: received})
+		database.SetNode(data.Convert(&meshv1.Node{Name: "node" + strconv.Itoa(i), Target: target}, NodeOk))
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeLeft))
+
+	m := &Mesh{
+		database:      database,
+		logger:        zap.NewNop().Sugar(),
+		setupConfig:   &SetupConfiguration{Name: "owl", JoinAddress: "127.0.0.1:8081"},
+		routineConfig: &RoutineConfiguration{RequestTimeout: time.Second},
+		clients:       map[uint32]*MeshClient{},
+	}
+	// the leave requests are sent in parallel
+	m.Leave()
+
+	if len(received) != 3 {
+		t.Fatalf("the amount of leave requests (%v) is not as expected: 3", len(received))
+	}
+	for i := 0; i < 3; i++ {
+		if update := <-received; update.Node.Name != "owl" || update.State != NodeLeft {
+			t.Errorf("the leave request is not as expected: %+v", update)
+		}
+	}
+}
+
+func Test_Reconcile(t *testing.T) {
+	// the target knows another node, but not this node
+	targetDatabase, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	targetDatabase.SetNode(data.Convert(&meshv1.Node{Name: "eagle", Target: "eagle:8081"}, NodeOk))
+	discovered := make(chan NodeDiscovered, 1)
+	name := "swan"
+	s := &MeshServer{
+		log:               zap.NewNop().Sugar(),
+		data:              targetDatabase,
+		name:              &name,
+		newNodeDiscovered: discovered,
+		nodeStateUpdate:   make(chan NodeStateUpdate, 4),
+	}
+	target := startTestServer(t, s)
+	s.ownNode = func() *meshv1.Node { return &meshv1.Node{Name: name, Target: target} }
+
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	m := &Mesh{
+		database:         database,
+		logger:           zap.NewNop().Sugar(),
+		setupConfig:      &SetupConfiguration{Name: "owl", JoinAddress: "owl:8081"},
+		routineConfig:    &RoutineConfiguration{RequestTimeout: time.Second, BroadcastToAmount: 2},
+		clients:          map[uint32]*MeshClient{},
+		sampleWatermarks: map[uint32]*sampleWatermark{},
+		nodeStateUpdate:  make(chan NodeStateUpdate, 4),
+	}
+	m.Reconcile([]string{"owl:8081", target})
+
+	// the target and its known nodes are added
+	for _, name := range []string{"swan", "eagle"} {
+		if node := database.GetNodeByName(name); node.State != NodeOk {
+			t.Errorf("node %v is not added: %+v", name, node)
+		}
+	}
+
+	// the unknown node is discovered by the target
+	select {
+	case discovery := <-discovered:
+		if discovery.NewNode.Name != "owl" || discovery.From != GetId(discovery.NewNode) {
+			t.Errorf("the discovery is not as expected: %+v", discovery)
+		}
+	default:
+		t.Error("the target did not discover this node")
+	}
+
+	// a known node reconciles without rejoining
+	targetDatabase.SetNode(data.Convert(m.ownNode(), NodeOk))
+	m.Reconcile([]string{target})
+	if len(discovered) != 0 {
+		t.Errorf("the known node rejoined: %+v", <-discovered)
+	}
+}
+
+// newTestMesh serves a mesh on a random local port and runs its channel routines
+func newTestMesh(t *testing.T, name string) *Mesh {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	m := &Mesh{
+		database:          database,
+		logger:            zap.NewNop().Sugar(),
+		setupConfig:       &SetupConfiguration{Name: name},
+		routineConfig:     &RoutineConfiguration{RequestTimeout: time.Second, BroadcastToAmount: 2, TombstoneTTL: time.Hour},
+		clients:           map[uint32]*MeshClient{},
+		sampleWatermarks:  map[uint32]*sampleWatermark{},
+		newNodeDiscovered: make(chan NodeDiscovered),
+		nodeStateUpdate:   make(chan NodeStateUpdate),
+		joinRoutineDone:   true,
+	}
+	s := &MeshServer{
+		log:               m.logger,
+		data:              database,
+		name:              &m.setupConfig.Name,
+		tombstoneTTL:      m.routineConfig.TombstoneTTL,
+		ping:              m.ping,
+		ownNode:           m.ownNode,
+		newNodeDiscovered: m.newNodeDiscovered,
+		nodeStateUpdate:   m.nodeStateUpdate,
+	}
+	m.setupConfig.JoinAddress = startTestServer(t, s)
+	go m.channelRoutines()
+	return m
+}
+
+func Test_ReconcileSplitMesh(t *testing.T) {
+	owl := newTestMesh(t, "owl")
+	swan := newTestMesh(t, "swan")
+
+	// both nodes declared each other dead, the tombstones are not gossiped anymore
+	for _, pair := range [][2]*Mesh{{owl, swan}, {swan, owl}} {
+		removed := data.Convert(pair[1].ownNode(), NodeDead)
+		removed.StateChangeTs = time.Now().Add(-2 * time.Hour).Unix()
+		pair[0].database.SetNode(removed)
+	}
+
+	// the responder is alive and the requester refutes its own tombstone
+	owl.Reconcile([]string{swan.setupConfig.JoinAddress})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if owl.database.GetNodeByName("swan").State == NodeOk && swan.database.GetNodeByName("owl").State == NodeOk {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if node := owl.database.GetNodeByName("swan"); node.State != NodeOk {
+		t.Errorf("the responder is not alive: %+v", node)
+	}
+	if node := swan.database.GetNodeByName("owl"); node.State != NodeOk || node.Incarnation != 1 {
+		t.Errorf("the requester did not refute its tombstone: %+v", node)
+	}
+}
+
+func Test_MeshPullState(t *testing.T) {
+	// the pulled node has a newer and an outdated sample and knows another node
+	targetDatabase, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	targetDatabase.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081"}, NodeOk))
+	targetDatabase.SetNode(data.Convert(&meshv1.Node{Name: "eagle", Target: "eagle:8081"}, NodeOk))
+	targetDatabase.SetSample(&data.Sample{From: "swan", To: "eagle", Key: data.RttTotal, Value: 2, Ts: 20})
+	targetDatabase.SetSample(&data.Sample{From: "eagle", To: "swan", Key: data.RttTotal, Value: 2, Ts: 10})
+
+	name := "swan"
+	s := &MeshServer{log: zap.NewNop().Sugar(), data: targetDatabase, name: &name}
+	target := startTestServer(t, s)
+	s.ownNode = func() *meshv1.Node { return &meshv1.Node{Name: name, Target: target} }
+
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	swan := &meshv1.Node{Name: name, Target: target}
+	database.SetNode(data.Convert(swan, NodeOk))
+	database.SetSample(&data.Sample{From: "swan", To: "eagle", Key: data.RttTotal, Value: 1, Ts: 10})
+	database.SetSample(&data.Sample{From: "eagle", To: "swan", Key: data.RttTotal, Value: 1, Ts: 20})
+
+	m := &Mesh{
+		database:        database,
+		logger:          zap.NewNop().Sugar(),
+		setupConfig:     &SetupConfiguration{Name: "owl", JoinAddress: "owl:8081"},
+		routineConfig:   &RoutineConfiguration{RequestTimeout: time.Second, BroadcastToAmount: 2},
+		clients:         map[uint32]*MeshClient{},
+		nodeStateUpdate: make(chan NodeStateUpdate, 4),
+	}
+	m.PullState(swan)
+
+	// the newer sample is taken, the outdated one is kept
+	values := map[string]float64{}
+	for _, sample := range database.GetSampleList() {
+		values[sample.From+"->"+sample.To] = sample.Value
+	}
+	if diff := deep.Equal(values, map[string]float64{"swan->eagle": 2, "eagle->swan": 1}); diff != nil {
+		t.Error(diff)
+	}
+	if node := database.GetNodeByName("eagle"); node.State != NodeOk {
+		t.Errorf("the pulled node is not added: %+v", node)
+	}
+	if node := database.GetNodeByName("owl"); node.Id != 0 {
+		t.Errorf("this node is added: %+v", node)
+	}
+}
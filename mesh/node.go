@@ -21,8 +21,72 @@
 
 package mesh
 
+import (
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
+)
+
 const (
-	NodeOk      = 1
-	NodeTimeout = 2
-	NodeDead    = 3
+	NodeOk      = data.NodeStateOk
+	NodeTimeout = data.NodeStateTimeout
+	NodeDead    = data.NodeStateDead
+	NodeLeft    = data.NodeStateLeft
 )
+
+// overridesNodeState checks if a gossiped state of a node with its incarnation
+// is newer than the known node.
+// A node refutes a suspicion or its death by increasing its incarnation.
+func overridesNodeState(state int, incarnation uint64, known *data.Node) bool {
+	// unknown node; just an alive node will be added
+	if known.Id == 0 {
+		return state == NodeOk
+	}
+
+	switch state {
+	case NodeOk:
+		return incarnation > known.Incarnation
+	case NodeTimeout:
+		if known.State == NodeOk {
+			return incarnation >= known.Incarnation
+		}
+		return incarnation > known.Incarnation
+	case NodeDead:
+		return known.State != NodeDead && incarnation >= known.Incarnation
+	case NodeLeft:
+		return known.State != NodeLeft && incarnation >= known.Incarnation
+	}
+	return false
+}
+
+// isRemoved checks if the node is a tombstone of a dead or left node
+func isRemoved(node *data.Node) bool {
+	return node.Id != 0 && (node.State == NodeDead || node.State == NodeLeft)
+}
+
+// isTombstoneActive checks if the node is a tombstone removed within the ttl.
+// Active tombstones are gossiped to keep peers from re-adding the node.
+func isTombstoneActive(node *data.Node, ttl time.Duration) bool {
+	return isRemoved(node) && time.Unix(node.StateChangeTs, 0).After(time.Now().Add(-ttl))
+}
+
+// isSampleAccepted checks if an incoming sample is measured from and to nodes of the mesh.
+// Samples of removed or unknown nodes are rejected, so purged samples are not re-added.
+// Unknown receivers can be targets of probes, their samples are rejected if older than the tombstone ttl.
+func isSampleAccepted(store data.Store, own string, ttl time.Duration, sample *meshv1.Sample) bool {
+	if sample.From != own {
+		from := store.GetNodeByName(sample.From)
+		if from.Id == 0 || isRemoved(from) {
+			return false
+		}
+	}
+	if sample.To == own {
+		return true
+	}
+	to := store.GetNodeByName(sample.To)
+	if to.Id == 0 {
+		return time.Unix(sample.Ts, 0).After(time.Now().Add(-ttl))
+	}
+	return !isRemoved(to)
+}
@@ -0,0 +1,186 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mesh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
+	"go.uber.org/zap"
+)
+
+func Test_overridesNodeState(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       int
+		incarnation uint64
+		known       *data.Node
+		expected    bool
+	}{
+		{name: "unknown node alive", state: NodeOk, incarnation: 0, known: &data.Node{}, expected: true},
+		{name: "unknown node suspected", state: NodeTimeout, incarnation: 0, known: &data.Node{}, expected: false},
+		{name: "unknown node dead", state: NodeDead, incarnation: 0, known: &data.Node{}, expected: false},
+
+		{name: "alive with same incarnation", state: NodeOk, incarnation: 1, known: &data.Node{Id: 1, State: NodeTimeout, Incarnation: 1}, expected: false},
+		{name: "alive refutes suspicion", state: NodeOk, incarnation: 2, known: &data.Node{Id: 1, State: NodeTimeout, Incarnation: 1}, expected: true},
+
+		{name: "suspect alive node with same incarnation", state: NodeTimeout, incarnation: 1, known: &data.Node{Id: 1, State: NodeOk, Incarnation: 1}, expected: true},
+		{name: "suspect alive node with old incarnation", state: NodeTimeout, incarnation: 0, known: &data.Node{Id: 1, State: NodeOk, Incarnation: 1}, expected: false},
+		{name: "suspect already suspected node", state: NodeTimeout, incarnation: 1, known: &data.Node{Id: 1, State: NodeTimeout, Incarnation: 1}, expected: false},
+		{name: "suspect suspected node with newer incarnation", state: NodeTimeout, incarnation: 2, known: &data.Node{Id: 1, State: NodeTimeout, Incarnation: 1}, expected: true},
+
+		{name: "dead with same incarnation", state: NodeDead, incarnation: 1, known: &data.Node{Id: 1, State: NodeOk, Incarnation: 1}, expected: true},
+		{name: "dead with old incarnation", state: NodeDead, incarnation: 0, known: &data.Node{Id: 1, State: NodeTimeout, Incarnation: 1}, expected: false},
+		{name: "dead node already dead", state: NodeDead, incarnation: 1, known: &data.Node{Id: 1, State: NodeDead, Incarnation: 1}, expected: false},
+
+		{name: "left with same incarnation", state: NodeLeft, incarnation: 1, known: &data.Node{Id: 1, State: NodeOk, Incarnation: 1}, expected: true},
+		{name: "left node already left", state: NodeLeft, incarnation: 1, known: &data.Node{Id: 1, State: NodeLeft, Incarnation: 1}, expected: false},
+		{name: "alive after leaving with same incarnation", state: NodeOk, incarnation: 1, known: &data.Node{Id: 1, State: NodeLeft, Incarnation: 1}, expected: false},
+
+		{name: "unknown state", state: 0, incarnation: 5, known: &data.Node{Id: 1, State: NodeOk, Incarnation: 1}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := overridesNodeState(tt.state, tt.incarnation, tt.known)
+			if result != tt.expected {
+				t.Errorf("The result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_joinTargets(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081"}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081"}, NodeDead))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeLeft))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "me", Target: "me:8081"}, NodeOk))
+
+	m := &Mesh{
+		database: database,
+		setupConfig: &SetupConfiguration{
+			Targets:     []string{"owl:8081", "eagle:8081"},
+			JoinAddress: "me:8081",
+		},
+	}
+
+	// configured targets first, known nodes without duplicates, left nodes and this node
+	expected := []string{"owl:8081", "eagle:8081", "swan:8081"}
+	if diff := deep.Equal(m.joinTargets(), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_isTombstoneActive(t *testing.T) {
+	now := time.Now().Unix()
+	tests := []struct {
+		name     string
+		node     *data.Node
+		expected bool
+	}{
+		{name: "unknown node", node: &data.Node{}, expected: false},
+		{name: "healthy node", node: &data.Node{Id: 1, State: NodeOk, StateChangeTs: now}, expected: false},
+		{name: "suspected node", node: &data.Node{Id: 1, State: NodeTimeout, StateChangeTs: now}, expected: false},
+		{name: "dead node within ttl", node: &data.Node{Id: 1, State: NodeDead, StateChangeTs: now}, expected: true},
+		{name: "left node within ttl", node: &data.Node{Id: 1, State: NodeLeft, StateChangeTs: now - 60}, expected: true},
+		{name: "dead node over ttl", node: &data.Node{Id: 1, State: NodeDead, StateChangeTs: now - 7200}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isTombstoneActive(tt.node, time.Hour); result != tt.expected {
+				t.Errorf("result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_purgeNode(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081"}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081"}, NodeDead))
+	database.SetSample(&data.Sample{From: "owl", To: "swan", Key: data.RttTotal, Value: 1})
+	database.SetSample(&data.Sample{From: "swan", To: "owl", Key: data.RttTotal, Value: 1})
+	database.SetSample(&data.Sample{From: "owl", To: "me", Key: data.RttTotal, Value: 1})
+
+	m := &Mesh{
+		database:         database,
+		sampleWatermarks: map[uint32]*sampleWatermark{},
+	}
+	m.purgeNode(database.GetNodeByName("swan"))
+
+	nodes := []string{}
+	for _, node := range database.GetNodeList() {
+		nodes = append(nodes, node.Name)
+	}
+	if diff := deep.Equal(nodes, []string{"owl"}); diff != nil {
+		t.Error(diff)
+	}
+	samples := database.GetSampleList()
+	if len(samples) != 1 || samples[0].To != "me" {
+		t.Errorf("the samples of the purged node are not deleted: %+v", samples)
+	}
+}
+
+func Test_isSampleAccepted(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081"}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeTimeout))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081"}, NodeDead))
+
+	now := time.Now().Unix()
+	tests := []struct {
+		name     string
+		sample   *meshv1.Sample
+		expected bool
+	}{
+		{name: "between nodes", sample: &meshv1.Sample{From: "owl", To: "crow", Ts: now}, expected: true},
+		{name: "to own node", sample: &meshv1.Sample{From: "owl", To: "me", Ts: now - 7200}, expected: true},
+		{name: "from own node", sample: &meshv1.Sample{From: "me", To: "owl", Ts: now}, expected: true},
+		{name: "from unknown node", sample: &meshv1.Sample{From: "goose", To: "owl", Ts: now}, expected: false},
+		{name: "from removed node", sample: &meshv1.Sample{From: "swan", To: "owl", Ts: now}, expected: false},
+		{name: "to removed node", sample: &meshv1.Sample{From: "owl", To: "swan", Ts: now}, expected: false},
+		{name: "to probe target", sample: &meshv1.Sample{From: "owl", To: "https://example.com", Ts: now}, expected: true},
+		{name: "to outdated target", sample: &meshv1.Sample{From: "owl", To: "goose", Ts: now - 7200}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isSampleAccepted(database, "me", time.Hour, tt.sample); result != tt.expected {
+				t.Errorf("result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,607 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mesh
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+	h "github.com/telekom/canary-bot/helper"
+	"github.com/telekom/canary-bot/probe"
+	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
+
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// rttProbe measures the round-trip-time to a node of the mesh
+type rttProbe struct {
+	m *Mesh
+}
+
+func (p *rttProbe) Name() string {
+	return "rtt"
+}
+
+func (p *rttProbe) Interval() time.Duration {
+	return p.m.routineConfig.RttInterval
+}
+
+func (p *rttProbe) PeerCycle() bool {
+	return true
+}
+
+func (p *rttProbe) Timeout() time.Duration {
+	return p.m.routineConfig.RequestTimeout
+}
+
+// Run measures the RTT with and without the TCP handshake,
+// the duration of the DNS resolution, TCP connect and TLS handshake
+// and estimates the clock offset of the node
+func (p *rttProbe) Run(ctx context.Context, target probe.Target) []probe.Sample {
+	log := p.m.logger.Named("rtt")
+	var opts []grpc.DialOption
+	trace := &rttTrace{}
+	failed := []probe.Sample{
+		{Key: data.RttTotal, Failed: true},
+		{Key: data.RttRequest, Failed: true},
+		{Key: data.RttDns, Failed: true},
+		{Key: data.RttConnect, Failed: true},
+	}
+
+	// grpc logging
+	if p.m.setupConfig.DebugGrpc {
+		grpc_zap.ReplaceGrpcLoggerV2(log.Named("grpc").Desugar())
+	}
+
+	// TLS
+	tlsCredentials, err := h.LoadClientTLSCredentials(p.m.setupConfig.CaCertPath, p.m.setupConfig.CaCert)
+	if err != nil {
+		log.Debugw("Cannot load TLS credentials - starting insecure connection", "error", err.Error())
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(&timedCredentials{TransportCredentials: tlsCredentials, trace: trace}))
+		failed = append(failed, probe.Sample{Key: data.RttTls, Failed: true})
+	}
+
+	// measure DNS resolution & TCP connect
+	opts = append(opts, grpc.WithContextDialer(trace.dial))
+
+	// blocking
+	opts = append(opts, grpc.WithBlock())
+
+	// start RTT with TCP handshake
+	rttStartH := time.Now()
+	// dial
+	conn, err := grpc.DialContext(ctx, target.Address, opts...)
+	if err != nil {
+		log.Debugw("Dial error", "error", err)
+		return failed
+	}
+	defer conn.Close()
+
+	client := meshv1.NewMeshServiceClient(conn)
+
+	// start RTT without a TCP handshake
+	rttStart := time.Now()
+
+	// send request
+	res, err := client.Rtt(ctx, &emptypb.Empty{})
+	// end RTT
+	rttEnd := time.Now()
+
+	if err != nil {
+		log.Debugw("RTT failed", "error", err)
+		return failed
+	}
+	log.Debugw("RTT succeeded")
+
+	trace.mu.Lock()
+	durations := map[int64]time.Duration{
+		// RTT with handshake
+		data.RttTotal: rttEnd.Sub(rttStartH),
+		// RTT without handshake
+		data.RttRequest: rttEnd.Sub(rttStart),
+		data.RttDns:     trace.dns,
+		data.RttConnect: trace.connect,
+	}
+	if trace.secure {
+		durations[data.RttTls] = trace.tls
+	}
+	trace.mu.Unlock()
+
+	samples := make([]probe.Sample, 0, len(failed))
+	for _, f := range failed {
+		d := durations[f.Key]
+		p.m.metrics.GetRtt().WithLabelValues(p.m.sampleLabelValues(f.Key, target.Name, target.Labels)...).Observe(d.Seconds())
+		samples = append(samples, probe.Sample{Key: f.Key, Value: float64(d.Nanoseconds())})
+	}
+
+	// nodes without timestamp in the response do not support the clock offset estimation
+	if res.Ts != 0 {
+		samples = append(samples, p.clockOffset(target, rttStart, rttEnd, res.Ts)...)
+	}
+	return samples
+}
+
+// clockOffset estimates the clock offset of the node like NTP,
+// assuming the node answered in the middle of the request
+func (p *rttProbe) clockOffset(target probe.Target, start time.Time, end time.Time, ts int64) []probe.Sample {
+	offset := time.Duration(ts - (start.UnixNano()+end.UnixNano())/2)
+
+	exceeded := 0.0
+	if offset > p.m.routineConfig.ClockSkewThreshold || offset < -p.m.routineConfig.ClockSkewThreshold {
+		exceeded = 1
+		p.m.logger.Named("rtt").Warnw("Clock skew exceeds threshold", "node", target.Name, "offset", offset.String(), "threshold", p.m.routineConfig.ClockSkewThreshold.String())
+	}
+
+	return []probe.Sample{
+		{Key: data.ClockOffset, Value: float64(offset.Nanoseconds())},
+		{Key: data.ClockSkewExceeded, Value: exceeded},
+	}
+}
+
+// rttTrace holds the durations of the connection setup
+type rttTrace struct {
+	mu      sync.Mutex
+	dns     time.Duration
+	connect time.Duration
+	tls     time.Duration
+	secure  bool
+	// lookup resolves the host, the default resolver is used if nil
+	lookup func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// dial resolves the address and connects via TCP, measuring both steps.
+// The resolved addresses are tried in turn, the connect time is the one of the connected address.
+func (t *rttTrace) dial(ctx context.Context, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := t.lookup
+	if lookup == nil {
+		lookup = net.DefaultResolver.LookupIPAddr
+	}
+	start := time.Now()
+	ips, err := lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, errors.New("no IP address found for " + host)
+	}
+	dns := time.Since(start)
+
+	var dialer net.Dialer
+	var conn net.Conn
+	var connect time.Duration
+	for _, ip := range ips {
+		start = time.Now()
+		conn, err = dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			connect = time.Since(start)
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.dns = dns
+	t.connect = connect
+	t.mu.Unlock()
+	return conn, nil
+}
+
+// timedCredentials measures the duration of the TLS handshake
+type timedCredentials struct {
+	credentials.TransportCredentials
+	trace *rttTrace
+}
+
+func (c *timedCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	start := time.Now()
+	conn, authInfo, err := c.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	if err == nil {
+		c.trace.mu.Lock()
+		c.trace.tls = time.Since(start)
+		c.trace.secure = true
+		c.trace.mu.Unlock()
+	}
+	return conn, authInfo, err
+}
+
+func (c *timedCredentials) Clone() credentials.TransportCredentials {
+	return &timedCredentials{TransportCredentials: c.TransportCredentials.Clone(), trace: c.trace}
+}
+
+// lossProbe sends a burst of requests to a node of the mesh
+// and measures the loss, jitter and min/avg/max RTT
+type lossProbe struct {
+	m *Mesh
+}
+
+func (p *lossProbe) Name() string {
+	return "loss"
+}
+
+func (p *lossProbe) Interval() time.Duration {
+	return p.m.routineConfig.LossInterval
+}
+
+// Timeout includes the duration of sending the burst
+func (p *lossProbe) Timeout() time.Duration {
+	return time.Duration(p.m.routineConfig.LossBurstAmount)*p.m.routineConfig.LossBurstSpacing + p.m.routineConfig.LossRequestTimeout
+}
+
+// Run sends the burst over the existing connection to the node.
+// A request without response within the request timeout counts as lost.
+func (p *lossProbe) Run(ctx context.Context, target probe.Target) []probe.Sample {
+	log := p.m.logger.Named("loss")
+	keys := probe.BurstKeys{
+		Loss:   data.LossPercent,
+		Jitter: data.Jitter,
+		Min:    data.RttMin,
+		Avg:    data.RttAvg,
+		Max:    data.RttMax,
+	}
+
+	node := &meshv1.Node{Name: target.Name, Target: target.Address}
+	if err := p.m.initClient(node); err != nil {
+		log.Debugw("Could not connect to client")
+		return probe.BurstSamples(keys, nil, 0)
+	}
+	client := p.m.client(node)
+
+	var rtts []time.Duration
+	sent := 0
+	for sent < p.m.routineConfig.LossBurstAmount && ctx.Err() == nil {
+		if sent > 0 {
+			time.Sleep(p.m.routineConfig.LossBurstSpacing)
+		}
+		sent++
+
+		reqCtx, cancel := context.WithTimeout(ctx, p.m.routineConfig.LossRequestTimeout)
+		start := time.Now()
+		_, err := client.Rtt(reqCtx, &emptypb.Empty{})
+		rtt := time.Since(start)
+		cancel()
+		if err != nil {
+			log.Debugw("Request lost", "node", target.Name, "error", err)
+			continue
+		}
+		rtts = append(rtts, rtt)
+	}
+	return probe.BurstSamples(keys, rtts, sent)
+}
+
+// peerTlsProbe checks the TLS endpoints and the targets of all healthy peers
+type peerTlsProbe struct {
+	*probe.TLSProbe
+	m *Mesh
+}
+
+func (p *peerTlsProbe) Targets() []probe.Target {
+	targets := p.TLSProbe.Targets()
+	for _, node := range p.m.database.GetNodeListByState(NodeOk) {
+		targets = append(targets, probe.Target{
+			Name:    node.Name,
+			Address: node.Target,
+			Labels:  node.Labels,
+		})
+	}
+	return targets
+}
+
+// registerTlsProbe registers the TLS probe for the configured endpoints.
+// If the mesh uses TLS, the peers will be checked against the configured CA as well.
+func (m *Mesh) registerTlsProbe() error {
+	caCertPool, err := h.LoadCaCertPool(m.setupConfig.CaCertPath, m.setupConfig.CaCert)
+	meshTls := err == nil
+	if !meshTls && len(m.setupConfig.TlsTargets) == 0 {
+		return nil
+	}
+
+	tlsProbe, err := probe.NewTLSProbe(m.setupConfig.TlsTargets, caCertPool, m.routineConfig.TlsInterval, m.routineConfig.TlsTimeout)
+	if err != nil {
+		return err
+	}
+	if meshTls {
+		return m.routineConfig.Probes.Register(&peerTlsProbe{TLSProbe: tlsProbe, m: m})
+	}
+	return m.routineConfig.Probes.Register(tlsProbe)
+}
+
+// throughputProbe measures the throughput to a node of the mesh in both directions
+type throughputProbe struct {
+	m *Mesh
+}
+
+func (p *throughputProbe) Name() string {
+	return "throughput"
+}
+
+func (p *throughputProbe) Interval() time.Duration {
+	return p.m.routineConfig.ThroughputInterval
+}
+
+// Timeout includes the duration of both transfers
+func (p *throughputProbe) Timeout() time.Duration {
+	return 2*p.m.routineConfig.ThroughputDuration + p.m.routineConfig.RequestTimeout
+}
+
+// Run uploads and downloads chunks for the configured duration each.
+// The throughput is measured in bytes per second.
+func (p *throughputProbe) Run(ctx context.Context, target probe.Target) []probe.Sample {
+	log := p.m.logger.Named("throughput")
+
+	node := &meshv1.Node{Name: target.Name, Target: target.Address}
+	if err := p.m.initClient(node); err != nil {
+		log.Debugw("Could not connect to client")
+		return []probe.Sample{
+			{Key: data.ThroughputUp, Failed: true},
+			{Key: data.ThroughputDown, Failed: true},
+		}
+	}
+	client := p.m.client(node)
+
+	up := probe.Sample{Key: data.ThroughputUp, Failed: true}
+	if bytesPerSecond, err := p.upload(ctx, client); err != nil {
+		log.Debugw("Upload failed", "node", target.Name, "error", err)
+	} else {
+		up = probe.Sample{Key: data.ThroughputUp, Value: bytesPerSecond}
+	}
+
+	down := probe.Sample{Key: data.ThroughputDown, Failed: true}
+	if bytesPerSecond, err := p.download(ctx, client); err != nil {
+		log.Debugw("Download failed", "node", target.Name, "error", err)
+	} else {
+		down = probe.Sample{Key: data.ThroughputDown, Value: bytesPerSecond}
+	}
+
+	return []probe.Sample{up, down}
+}
+
+// upload sends chunks to the node, the throughput is measured by the node
+func (p *throughputProbe) upload(ctx context.Context, client meshv1.MeshServiceClient) (float64, error) {
+	stream, err := client.ThroughputUpload(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	chunk := &meshv1.ThroughputChunk{Payload: make([]byte, p.m.routineConfig.ThroughputChunkSize)}
+	end := time.Now().Add(p.m.routineConfig.ThroughputDuration)
+	for time.Now().Before(end) {
+		if err = stream.Send(chunk); err != nil {
+			// the node stopped receiving, the result holds the received bytes
+			break
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+	if res.Duration <= 0 {
+		return 0, errors.New("no chunks received by the node")
+	}
+	return float64(res.Bytes) / time.Duration(res.Duration).Seconds(), nil
+}
+
+// download receives chunks from the node for the configured duration
+func (p *throughputProbe) download(ctx context.Context, client meshv1.MeshServiceClient) (float64, error) {
+	stream, err := client.ThroughputDownload(ctx, &meshv1.ThroughputRequest{
+		ChunkSize: int64(p.m.routineConfig.ThroughputChunkSize),
+		Duration:  p.m.routineConfig.ThroughputDuration.Nanoseconds(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var bytes int64
+	var start time.Time
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if start.IsZero() {
+			start = time.Now()
+		}
+		bytes += int64(len(chunk.Payload))
+	}
+
+	if start.IsZero() {
+		return 0, errors.New("no chunks received from the node")
+	}
+	return float64(bytes) / time.Since(start).Seconds(), nil
+}
+
+// registerGrpcHealthProbe registers the gRPC health probe using the TLS settings of the mesh
+func (m *Mesh) registerGrpcHealthProbe() error {
+	creds, err := h.LoadClientTLSCredentials(m.setupConfig.CaCertPath, m.setupConfig.CaCert)
+	if err != nil {
+		creds = insecure.NewCredentials()
+	}
+
+	grpcHealthProbe, err := probe.NewGRPCHealthProbe(m.setupConfig.GrpcHealthTargets, creds, m.routineConfig.GrpcHealthInterval, m.routineConfig.GrpcHealthTimeout)
+	if err != nil {
+		return err
+	}
+	return m.routineConfig.Probes.Register(grpcHealthProbe)
+}
+
+// peerSchedule holds the names of the nodes not yet probed in the current cycle
+type peerSchedule struct {
+	mu    sync.Mutex
+	queue []string
+}
+
+// probeRoutine runs the probe on every tick of its ticker
+func (m *Mesh) probeRoutine(p probe.Probe, ticker *time.Ticker) {
+	schedule := &peerSchedule{}
+	for range ticker.C {
+		m.runProbe(p, schedule)
+	}
+}
+
+// runProbe runs the probe against its own targets or healthy nodes of the mesh.
+// Unless the probe cycles through the peers a random node is chosen.
+func (m *Mesh) runProbe(p probe.Probe, schedule *peerSchedule) {
+	log := m.logger.Named("probe-routine")
+	log.Debugw("Starting probe", "probe", p.Name())
+
+	var targets []probe.Target
+	if provider, ok := p.(probe.TargetProvider); ok {
+		targets = provider.Targets()
+	} else {
+		var nodes []*data.Node
+		if m.cyclesPeers(p) {
+			nodes = m.nextPeers(schedule, p.Interval())
+		} else {
+			nodes = m.database.GetRandomNodeListByState(NodeOk, 1)
+		}
+		if len(nodes) == 0 {
+			log.Debugw("No node suitable for probe", "probe", p.Name())
+			return
+		}
+		for _, node := range nodes {
+			targets = append(targets, probe.Target{
+				Name:    node.Name,
+				Address: node.Target,
+				Labels:  node.Labels,
+			})
+		}
+	}
+
+	for _, target := range targets {
+		go func(target probe.Target) {
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, target)
+			m.storeProbeSamples(target, samples, errors.Is(ctx.Err(), context.DeadlineExceeded))
+		}(target)
+	}
+}
+
+// cyclesPeers reports if the probe opted in to the probe cycle
+// and runs more than once per cycle.
+func (m *Mesh) cyclesPeers(p probe.Probe) bool {
+	cycler, ok := p.(probe.PeerCycler)
+	if !ok || !cycler.PeerCycle() {
+		return false
+	}
+	return m.routineConfig.ProbeCycle > 0 && p.Interval() < m.routineConfig.ProbeCycle
+}
+
+// nextPeers returns the next healthy nodes of the schedule.
+// The nodes are shuffled once per cycle and as many nodes are returned per interval
+// that every node is probed at least once per probe cycle.
+func (m *Mesh) nextPeers(schedule *peerSchedule, interval time.Duration) []*data.Node {
+	healthy := len(m.database.GetNodeListByState(NodeOk))
+	if healthy == 0 {
+		return nil
+	}
+	amount := int(math.Ceil(float64(healthy) * float64(interval) / float64(m.routineConfig.ProbeCycle)))
+	if amount > healthy {
+		amount = healthy
+	}
+
+	schedule.mu.Lock()
+	defer schedule.mu.Unlock()
+
+	var nodes []*data.Node
+	picked := map[string]bool{}
+	refilled := false
+	for len(nodes) < amount {
+		if len(schedule.queue) == 0 {
+			if refilled {
+				break
+			}
+			// start a new cycle
+			for _, node := range m.database.GetRandomNodeListByState(NodeOk, healthy) {
+				schedule.queue = append(schedule.queue, node.Name)
+			}
+			refilled = true
+			continue
+		}
+
+		name := schedule.queue[0]
+		schedule.queue = schedule.queue[1:]
+
+		// the node could have changed its state since the cycle started
+		node := m.database.GetNodeByName(name)
+		if node.Id == 0 || node.State != NodeOk || picked[name] {
+			continue
+		}
+		picked[name] = true
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// storeProbeSamples saves the samples of a probe run and updates the sample metric.
+// Failed samples are saved as NaN with a timeout status if the probe timed out.
+func (m *Mesh) storeProbeSamples(target probe.Target, samples []probe.Sample, timedOut bool) {
+	for _, sample := range samples {
+		value := sample.Value
+		status := data.SampleStatusOk
+		if sample.Failed {
+			value = math.NaN()
+			status = data.SampleStatusError
+			if timedOut {
+				status = data.SampleStatusTimeout
+			}
+		}
+
+		m.database.SetSample(
+			&data.Sample{
+				From:   m.setupConfig.Name,
+				To:     target.Name,
+				Key:    sample.Key,
+				Value:  value,
+				Status: status,
+				Unit:   data.SampleUnit[sample.Key],
+				Ts:     time.Now().Unix(),
+			},
+		)
+		m.metrics.GetSample().WithLabelValues(m.sampleLabelValues(sample.Key, target.Name, target.Labels)...).Set(value)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mesh
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+	"github.com/telekom/canary-bot/probe"
+	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func Test_rttTraceDial(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	defer listener.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	resolve := func(ips ...string) func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return func(ctx context.Context, host string) ([]net.IPAddr, error) {
+			addrs := []net.IPAddr{}
+			for _, ip := range ips {
+				addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+			}
+			return addrs, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		addr    string
+		lookup  func(ctx context.Context, host string) ([]net.IPAddr, error)
+		wantErr bool
+	}{
+		{
+			name: "IP address",
+			addr: listener.Addr().String(),
+		},
+		{
+			name:   "Unreachable first address",
+			addr:   net.JoinHostPort("canary.test", port),
+			lookup: resolve("127.0.0.2", "127.0.0.1"),
+		},
+		{
+			name:    "All addresses unreachable",
+			addr:    net.JoinHostPort("canary.test", port),
+			lookup:  resolve("127.0.0.2", "127.0.0.3"),
+			wantErr: true,
+		},
+		{
+			name:    "Missing port",
+			addr:    "127.0.0.1",
+			wantErr: true,
+		},
+		{
+			name:    "Connection refused",
+			addr:    net.JoinHostPort("127.0.0.1", "1"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := &rttTrace{lookup: tt.lookup}
+			conn, err := trace.dial(context.Background(), tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			if conn.RemoteAddr().String() != net.JoinHostPort("127.0.0.1", port) {
+				t.Errorf("connected to %v, expected %v", conn.RemoteAddr(), listener.Addr())
+			}
+			if trace.connect <= 0 {
+				t.Errorf("connect duration %v is not positive", trace.connect)
+			}
+		})
+	}
+}
+
+func Test_clockOffset(t *testing.T) {
+	p := &rttProbe{m: &Mesh{
+		logger:        zap.NewNop().Sugar(),
+		routineConfig: &RoutineConfiguration{ClockSkewThreshold: time.Second},
+	}}
+	start := time.Unix(100, 0)
+	end := start.Add(20 * time.Millisecond)
+
+	tests := []struct {
+		name string
+		ts   time.Time
+		want []probe.Sample
+	}{
+		{
+			name: "Synchronized clocks",
+			ts:   start.Add(10 * time.Millisecond),
+			want: []probe.Sample{
+				{Key: data.ClockOffset, Value: 0},
+				{Key: data.ClockSkewExceeded, Value: 0},
+			},
+		},
+		{
+			name: "Node is behind",
+			ts:   start.Add(-490 * time.Millisecond),
+			want: []probe.Sample{
+				{Key: data.ClockOffset, Value: float64(-500 * time.Millisecond)},
+				{Key: data.ClockSkewExceeded, Value: 0},
+			},
+		},
+		{
+			name: "Node is ahead over threshold",
+			ts:   start.Add(2010 * time.Millisecond),
+			want: []probe.Sample{
+				{Key: data.ClockOffset, Value: float64(2 * time.Second)},
+				{Key: data.ClockSkewExceeded, Value: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.clockOffset(probe.Target{Name: "owl"}, start, end, tt.ts.UnixNano())
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_throughputProbeRun(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	server := grpc.NewServer()
+	meshv1.RegisterMeshServiceServer(server, &MeshServer{log: zap.NewNop().Sugar()})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	m := &Mesh{
+		logger:      zap.NewNop().Sugar(),
+		setupConfig: &SetupConfiguration{},
+		routineConfig: &RoutineConfiguration{
+			RequestTimeout:      time.Second,
+			ThroughputDuration:  50 * time.Millisecond,
+			ThroughputChunkSize: 1024,
+		},
+		clients: map[uint32]*MeshClient{},
+	}
+	p := &throughputProbe{m: m}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+	defer cancel()
+	samples := p.Run(ctx, probe.Target{Name: "owl", Address: listener.Addr().String()})
+
+	if len(samples) != 2 {
+		t.Fatalf("the amount of samples (%v) is not as expected: 2", len(samples))
+	}
+	for _, sample := range samples {
+		if sample.Failed || sample.Value <= 0 {
+			t.Errorf("sample %v is not as expected: %+v", data.SampleName[sample.Key], sample)
+		}
+	}
+}
+
+func Test_nextPeers(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	for _, name := range []string{"owl", "swan", "eagle", "goose"} {
+		database.SetNode(data.Convert(&meshv1.Node{Name: name, Target: name + ":8081"}, NodeOk))
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeTimeout))
+
+	m := &Mesh{
+		database:      database,
+		routineConfig: &RoutineConfiguration{ProbeCycle: 4 * time.Second},
+	}
+	schedule := &peerSchedule{}
+
+	// 4 healthy nodes in a cycle of 4 intervals: one node per interval
+	probed := map[string]int{}
+	for i := 0; i < 8; i++ {
+		nodes := m.nextPeers(schedule, time.Second)
+		if len(nodes) != 1 {
+			t.Fatalf("the amount of nodes (%v) is not as expected: 1", len(nodes))
+		}
+		probed[nodes[0].Name]++
+	}
+	want := map[string]int{"owl": 2, "swan": 2, "eagle": 2, "goose": 2}
+	if diff := deep.Equal(probed, want); diff != nil {
+		t.Error(diff)
+	}
+
+	// 4 healthy nodes in a cycle of 2 intervals: two nodes per interval
+	if nodes := m.nextPeers(schedule, 2*time.Second); len(nodes) != 2 {
+		t.Errorf("the amount of nodes (%v) is not as expected: 2", len(nodes))
+	}
+}
+
+func Test_cyclesPeers(t *testing.T) {
+	tests := []struct {
+		name  string
+		cycle time.Duration
+		probe func(m *Mesh) probe.Probe
+		want  bool
+	}{
+		{
+			name:  "RTT without cycle",
+			cycle: 0,
+			probe: func(m *Mesh) probe.Probe { return &rttProbe{m: m} },
+			want:  false,
+		},
+		{
+			name:  "RTT with cycle",
+			cycle: time.Minute,
+			probe: func(m *Mesh) probe.Probe { return &rttProbe{m: m} },
+			want:  true,
+		},
+		{
+			name:  "RTT running once per cycle",
+			cycle: time.Second,
+			probe: func(m *Mesh) probe.Probe { return &rttProbe{m: m} },
+			want:  false,
+		},
+		{
+			name:  "probe not opting in",
+			cycle: time.Minute,
+			probe: func(m *Mesh) probe.Probe { return &lossProbe{m: m} },
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Mesh{routineConfig: &RoutineConfiguration{ProbeCycle: tt.cycle, RttInterval: time.Second, LossInterval: time.Second}}
+			if got := m.cyclesPeers(tt.probe(m)); got != tt.want {
+				t.Errorf("cyclesPeers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
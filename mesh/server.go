@@ -23,32 +23,86 @@ package mesh
 
 import (
 	"context"
+	"io"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/telekom/canary-bot/data"
 	h "github.com/telekom/canary-bot/helper"
 	"github.com/telekom/canary-bot/metric"
+	"github.com/telekom/canary-bot/probe"
 	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 )
 
+// Limits of a throughput measurement requested by another node
+const (
+	maxThroughputChunkSize = 1 << 20
+	maxThroughputDuration  = time.Second * 10
+)
+
+// isValidNode checks if the node of a request has a name and a target
+func isValidNode(node *meshv1.Node) bool {
+	return node != nil && node.Name != "" && node.Target != ""
+}
+
+// isCaller checks if the request is sent from the host of the node
+func isCaller(ctx context.Context, node *meshv1.Node) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	caller, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(node.Target)
+	if err != nil {
+		host = node.Target
+	}
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return false
+	}
+	for _, ip := range ips {
+		if ip.IP.Equal(net.ParseIP(caller)) {
+			return true
+		}
+	}
+	return false
+}
+
 // MeshServer handles incoming requests
 type MeshServer struct {
 	meshv1.UnimplementedMeshServiceServer
 	metrics metric.Metrics
 	log     *zap.SugaredLogger
-	data    *data.Database
+	data    data.Store
 	name    *string
 
+	// tombstoneTTL is the time removed nodes are sent to pulling nodes until they are deleted
+	tombstoneTTL time.Duration
+
+	// ping is used to ping nodes on behalf of other nodes
+	ping func(node *meshv1.Node) error
+	// ownNode returns this node for responses
+	ownNode func() *meshv1.Node
+
 	newNodeDiscovered chan NodeDiscovered
+	nodeStateUpdate   chan NodeStateUpdate
 }
 
 // JoinMesh handles the join mesh request from a node wishing to join the mesh
@@ -63,12 +117,31 @@ func (s *MeshServer) JoinMesh(ctx context.Context, req *meshv1.Node) (*meshv1.Jo
 
 	var nodes []*meshv1.Node
 	for _, datanode := range s.data.GetNodeList() {
-		nodes = append(nodes, &meshv1.Node{Name: datanode.Name, Target: datanode.Target})
+		// the joining node would add removed nodes as healthy
+		if isRemoved(datanode) {
+			continue
+		}
+		nodes = append(nodes, datanode.Convert())
 	}
 	res := meshv1.JoinMeshResponse{NameUnique: true, MyName: *s.name, Nodes: nodes}
 	return &res, nil
 }
 
+// LeaveMesh handles the leave mesh request from a node leaving the mesh
+// Just the leaving node itself can send the request.
+func (s *MeshServer) LeaveMesh(ctx context.Context, req *meshv1.Node) (*emptypb.Empty, error) {
+	if !isValidNode(req) {
+		return nil, status.Error(codes.InvalidArgument, "the leaving node needs a name and a target")
+	}
+	if !isCaller(ctx, req) {
+		s.log.Warnw("Leave mesh request not sent by the leaving node", "node", req.Name)
+		return nil, status.Error(codes.PermissionDenied, "just the leaving node can leave the mesh")
+	}
+	s.log.Infow("Leave mesh request", "node", req.Name)
+	s.nodeStateUpdate <- NodeStateUpdate{req, NodeLeft, GetId(req)}
+	return &emptypb.Empty{}, nil
+}
+
 // Ping handles the ping request from a node in the mesh
 func (s *MeshServer) Ping(ctx context.Context, req *meshv1.Node) (*emptypb.Empty, error) {
 	if req != nil {
@@ -77,32 +150,165 @@ func (s *MeshServer) Ping(ctx context.Context, req *meshv1.Node) (*emptypb.Empty
 	return &emptypb.Empty{}, nil
 }
 
+// PingReq handles the indirect ping request from a node in the mesh.
+// The target node will be pinged on behalf of the requesting node,
+// if it is a known node of the mesh.
+func (s *MeshServer) PingReq(ctx context.Context, req *meshv1.PingReqRequest) (*meshv1.PingReqResponse, error) {
+	if !isValidNode(req.Target) {
+		return nil, status.Error(codes.InvalidArgument, "the target needs a name and a target")
+	}
+	known := s.data.GetNodeByName(req.Target.Name)
+	if known.Id == 0 || isRemoved(known) || known.Target != req.Target.Target {
+		return nil, status.Error(codes.NotFound, "the target is not a node of the mesh")
+	}
+
+	err := s.ping(known.Convert())
+	if err != nil {
+		s.log.Debugw("Indirect ping failed", "node", req.Target.Name, "requested by", req.IAmNode.GetName())
+	}
+	return &meshv1.PingReqResponse{Ok: err == nil}, nil
+}
+
 // NodeDiscovery handles the node discovery request from a node in the mesh
 func (s *MeshServer) NodeDiscovery(ctx context.Context, req *meshv1.NodeDiscoveryRequest) (*emptypb.Empty, error) {
+	if !isValidNode(req.NewNode) || !isValidNode(req.IAmNode) {
+		return nil, status.Error(codes.InvalidArgument, "the new node and the requesting node need a name and a target")
+	}
 	s.newNodeDiscovered <- NodeDiscovered{req.NewNode, GetId(req.IAmNode)}
 	return &emptypb.Empty{}, nil
 }
 
-// PushSamples adds samples to the database if they are newer than the current sample
-func (s *MeshServer) PushSamples(ctx context.Context, req *meshv1.Samples) (*emptypb.Empty, error) {
+// NodeStateUpdate handles the gossiped state of a node from a node in the mesh
+func (s *MeshServer) NodeStateUpdate(ctx context.Context, req *meshv1.NodeStateUpdateRequest) (*emptypb.Empty, error) {
+	if !isValidNode(req.Node) || !isValidNode(req.IAmNode) {
+		return nil, status.Error(codes.InvalidArgument, "the node and the requesting node need a name and a target")
+	}
+	s.nodeStateUpdate <- NodeStateUpdate{req.Node, int(req.State), GetId(req.IAmNode)}
+	return &emptypb.Empty{}, nil
+}
+
+// PushSamples adds samples to the database if they are newer than the current sample.
+// The sequence number of the pushed samples will be acknowledged.
+func (s *MeshServer) PushSamples(ctx context.Context, req *meshv1.Samples) (*meshv1.PushSamplesResponse, error) {
+	var accepted int64
 	for _, sample := range req.Samples {
+		if !isSampleAccepted(s.data, *s.name, s.tombstoneTTL, sample) {
+			continue
+		}
 		if sample.Ts > s.data.GetSampleTs(GetSampleId(sample)) {
-			s.data.SetSample(&data.Sample{
-				From:  sample.From,
-				To:    sample.To,
-				Key:   sample.Key,
-				Value: sample.Value,
-				Ts:    sample.Ts,
-			})
+			s.data.SetSample(data.ConvertSample(sample))
+			accepted++
 		}
 	}
-	s.log.Debugw("Safe samples", "count", len(s.data.GetSampleList()))
-	return &emptypb.Empty{}, nil
+	s.log.Debugw("Safe samples", "received", len(req.Samples), "accepted", accepted)
+	return &meshv1.PushSamplesResponse{Seq: req.Seq, Accepted: accepted}, nil
+}
+
+// PullState handles the anti-entropy request from a node in the mesh.
+// The samples and nodes the node is missing or has stale will be returned.
+// An unknown requesting node is discovered, e.g. a node reconciling a split mesh.
+func (s *MeshServer) PullState(ctx context.Context, req *meshv1.StateDigest) (*meshv1.PullStateResponse, error) {
+	if !isValidNode(req.IAmNode) {
+		return nil, status.Error(codes.InvalidArgument, "the requesting node needs a name and a target")
+	}
+	res := &meshv1.PullStateResponse{IAmNode: s.ownNode()}
+
+	if s.data.GetNodeByName(req.IAmNode.Name).Id == 0 && req.IAmNode.Name != *s.name {
+		s.newNodeDiscovered <- NodeDiscovered{req.IAmNode, GetId(req.IAmNode)}
+	}
+
+	for _, sample := range s.data.GetSampleList() {
+		if ts, exists := req.Samples[sample.Id]; !exists || sample.Ts > ts {
+			res.Samples = append(res.Samples, sample.Convert())
+		}
+	}
+
+	for _, node := range s.data.GetNodeList() {
+		// a removed requester can refute its own tombstone, even if it is not gossiped anymore
+		requester := isRemoved(node) && node.Name == req.IAmNode.GetName()
+		if requester || isTombstoneActive(node, s.tombstoneTTL) {
+			res.Tombstones = append(res.Tombstones, &meshv1.Tombstone{Node: node.Convert(), State: int64(node.State)})
+			continue
+		}
+		if node.State != NodeOk || node.Name == req.IAmNode.GetName() {
+			continue
+		}
+		if incarnation, exists := req.Nodes[node.Name]; !exists || node.Incarnation > incarnation {
+			res.Nodes = append(res.Nodes, node.Convert())
+		}
+	}
+
+	s.log.Debugw("Pull state", "node", req.IAmNode.GetName(), "samples", len(res.Samples), "nodes", len(res.Nodes))
+	return res, nil
 }
 
 // Rtt handles the round trip time request from a node in the mesh
-func (s *MeshServer) Rtt(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
-	return &emptypb.Empty{}, nil
+func (s *MeshServer) Rtt(ctx context.Context, req *emptypb.Empty) (*meshv1.RttResponse, error) {
+	return &meshv1.RttResponse{Ts: time.Now().UnixNano()}, nil
+}
+
+// ThroughputUpload receives chunks from a node and returns the amount of received bytes
+func (s *MeshServer) ThroughputUpload(stream meshv1.MeshService_ThroughputUploadServer) error {
+	var bytes int64
+	var start time.Time
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if start.IsZero() {
+			start = time.Now()
+		}
+		bytes += int64(len(chunk.Payload))
+		if time.Since(start) > maxThroughputDuration {
+			break
+		}
+	}
+
+	var duration time.Duration
+	if !start.IsZero() {
+		duration = time.Since(start)
+	}
+	return stream.SendAndClose(&meshv1.ThroughputResponse{Bytes: bytes, Duration: duration.Nanoseconds()})
+}
+
+// ThroughputDownload sends chunks to a node for the requested duration
+func (s *MeshServer) ThroughputDownload(req *meshv1.ThroughputRequest, stream meshv1.MeshService_ThroughputDownloadServer) error {
+	chunkSize := req.ChunkSize
+	if chunkSize <= 0 || chunkSize > maxThroughputChunkSize {
+		chunkSize = maxThroughputChunkSize
+	}
+	duration := time.Duration(req.Duration)
+	if duration <= 0 || duration > maxThroughputDuration {
+		duration = maxThroughputDuration
+	}
+
+	chunk := &meshv1.ThroughputChunk{Payload: make([]byte, chunkSize)}
+	end := time.Now().Add(duration)
+	for time.Now().Before(end) {
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartUdpEcho starts the UDP echo listener for the packet loss probe of other nodes
+func (m *Mesh) StartUdpEcho() error {
+	listenAdd := m.setupConfig.ListenAddress + ":" + strconv.FormatInt(m.setupConfig.UdpEchoPort, 10)
+
+	log := m.logger.Named("udp-echo")
+	log.Infow("Start listening", "address", listenAdd)
+	conn, err := net.ListenPacket("udp", listenAdd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return probe.ServeUDPEcho(conn, log)
 }
 
 // StartServer starts the mesh server, by setting up gRPC and TLS.
@@ -110,9 +316,13 @@ func (m *Mesh) StartServer() error {
 	meshServer := &MeshServer{
 		log:               m.logger.Named("server"),
 		metrics:           m.metrics,
-		data:              &m.database,
+		data:              m.database,
 		name:              &m.setupConfig.Name,
+		tombstoneTTL:      m.routineConfig.TombstoneTTL,
+		ping:              m.ping,
+		ownNode:           m.ownNode,
 		newNodeDiscovered: m.newNodeDiscovered,
+		nodeStateUpdate:   m.nodeStateUpdate,
 	}
 
 	// gRPC debug mode for more logs
@@ -151,6 +361,10 @@ func (m *Mesh) StartServer() error {
 	// register gRPC listener
 	grpcServer := grpc.NewServer(opts...)
 	meshv1.RegisterMeshServiceServer(grpcServer, meshServer)
+	// standard health service for peers & Kubernetes
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(meshv1.MeshService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 	reflection.Register(grpcServer)
 	err = grpcServer.Serve(lis)
 	if err != nil {
@@ -0,0 +1,383 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mesh
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+	meshv1 "github.com/telekom/canary-bot/proto/mesh/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// startTestServer serves the mesh server on a random local port and returns the address
+func startTestServer(t *testing.T, s *MeshServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	server := grpc.NewServer()
+	meshv1.RegisterMeshServiceServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+// newTestClient connects to a test server
+func newTestClient(t *testing.T, target string) meshv1.MeshServiceClient {
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return meshv1.NewMeshServiceClient(conn)
+}
+
+func Test_PingReq(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	target := &meshv1.Node{Name: "swan", Target: "swan:8081"}
+	database.SetNode(data.Convert(target, NodeTimeout))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeDead))
+	owl := &meshv1.Node{Name: "owl", Target: "owl:8081"}
+
+	tests := []struct {
+		name     string
+		req      *meshv1.PingReqRequest
+		ping     func(node *meshv1.Node) error
+		code     codes.Code
+		expected bool
+	}{
+		{
+			name: "No target",
+			req:  &meshv1.PingReqRequest{IAmNode: owl},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Unknown target",
+			req:  &meshv1.PingReqRequest{Target: &meshv1.Node{Name: "metadata", Target: "169.254.169.254:80"}, IAmNode: owl},
+			code: codes.NotFound,
+		},
+		{
+			name: "Known name with another target",
+			req:  &meshv1.PingReqRequest{Target: &meshv1.Node{Name: "swan", Target: "169.254.169.254:80"}, IAmNode: owl},
+			code: codes.NotFound,
+		},
+		{
+			name: "Removed target",
+			req:  &meshv1.PingReqRequest{Target: &meshv1.Node{Name: "crow", Target: "crow:8081"}, IAmNode: owl},
+			code: codes.NotFound,
+		},
+		{
+			name:     "Ping failed",
+			req:      &meshv1.PingReqRequest{Target: target, IAmNode: owl},
+			ping:     func(node *meshv1.Node) error { return errors.New("unreachable") },
+			code:     codes.OK,
+			expected: false,
+		},
+		{
+			name: "Ping ok",
+			req:  &meshv1.PingReqRequest{Target: target, IAmNode: owl},
+			ping: func(node *meshv1.Node) error {
+				if node.Name != target.Name {
+					t.Errorf("pinged node (%v) is not as expected: %v", node.Name, target.Name)
+				}
+				return nil
+			},
+			code:     codes.OK,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ping := tt.ping
+			if ping == nil {
+				ping = func(node *meshv1.Node) error { t.Errorf("pinged %v", node.Target); return nil }
+			}
+			s := &MeshServer{log: zap.NewNop().Sugar(), data: database, ping: ping}
+			res, err := s.PingReq(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("the status code (%v) is not as expected: %v", code, tt.code)
+			}
+			if err == nil && res.Ok != tt.expected {
+				t.Errorf("result (%v) is not as expected: %v", res.Ok, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_ServerPullState(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081", Incarnation: 1}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081", Incarnation: 2}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "goose", Target: "goose:8081"}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeTimeout))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "eagle", Target: "eagle:8081"}, NodeOk))
+
+	current := &data.Sample{From: "owl", To: "swan", Key: data.RttTotal, Value: 1, Ts: 20}
+	stale := &data.Sample{From: "swan", To: "owl", Key: data.RttTotal, Value: 1, Ts: 20}
+	missing := &data.Sample{From: "owl", To: "goose", Key: data.RttTotal, Value: 1, Ts: 20}
+	for _, sample := range []*data.Sample{current, stale, missing} {
+		database.SetSample(sample)
+	}
+
+	name := "heron"
+	s := &MeshServer{
+		log:     zap.NewNop().Sugar(),
+		data:    database,
+		name:    &name,
+		ownNode: func() *meshv1.Node { return &meshv1.Node{Name: name, Target: "heron:8081"} },
+	}
+	res, err := s.PullState(context.Background(), &meshv1.StateDigest{
+		Samples: map[uint32]int64{current.Id: 20, stale.Id: 10},
+		// owl is known with the same, swan with an older incarnation
+		Nodes:   map[string]uint64{"owl": 1, "swan": 1, "eagle": 0},
+		IAmNode: &meshv1.Node{Name: "eagle", Target: "eagle:8081"},
+	})
+	if err != nil {
+		t.Fatalf("pull state failed: %v", err)
+	}
+
+	samples := map[string]bool{}
+	for _, sample := range res.Samples {
+		samples[sample.From+"->"+sample.To] = true
+	}
+	if diff := deep.Equal(samples, map[string]bool{"swan->owl": true, "owl->goose": true}); diff != nil {
+		t.Error(diff)
+	}
+
+	// unknown and newer nodes are returned, the suspected node and the requester are excluded
+	nodes := map[string]uint64{}
+	for _, node := range res.Nodes {
+		nodes[node.Name] = node.Incarnation
+	}
+	if diff := deep.Equal(nodes, map[string]uint64{"swan": 2, "goose": 0}); diff != nil {
+		t.Error(diff)
+	}
+	if res.IAmNode.GetName() != name {
+		t.Errorf("the responding node (%v) is not as expected: %v", res.IAmNode.GetName(), name)
+	}
+}
+
+func Test_PushSamplesPurgedNode(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081"}, NodeOk))
+	swan := data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081"}, NodeDead)
+	swan.StateChangeTs = time.Now().Add(-2 * time.Hour).Unix()
+	database.SetNode(swan)
+	measured := time.Now().Add(-3 * time.Hour).Unix()
+	database.SetSample(&data.Sample{From: "owl", To: "swan", Key: data.RttTotal, Value: 1, Ts: measured})
+
+	m := &Mesh{
+		database:         database,
+		sampleWatermarks: map[uint32]*sampleWatermark{},
+	}
+	m.purgeNode(database.GetNodeByName("swan"))
+
+	// a peer which has not purged the node yet pushes its samples back
+	name := "eagle"
+	s := &MeshServer{
+		log:          zap.NewNop().Sugar(),
+		data:         database,
+		name:         &name,
+		tombstoneTTL: time.Hour,
+	}
+	client := newTestClient(t, startTestServer(t, s))
+	res, err := client.PushSamples(context.Background(), &meshv1.Samples{Samples: []*meshv1.Sample{
+		{From: "owl", To: "swan", Key: data.RttTotal, Ts: measured},
+		{From: "swan", To: "owl", Key: data.RttTotal, Ts: measured},
+		{From: "owl", To: "eagle", Key: data.RttTotal, Ts: time.Now().Unix()},
+	}})
+	if err != nil {
+		t.Fatalf("push samples failed: %v", err)
+	}
+	if res.Accepted != 1 {
+		t.Errorf("the accepted samples (%v) are not as expected: 1", res.Accepted)
+	}
+	samples := database.GetSampleList()
+	if len(samples) != 1 || samples[0].To != "eagle" {
+		t.Errorf("the samples of the purged node are re-added: %+v", samples)
+	}
+}
+
+func Test_PullStateTombstones(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	database.SetNode(data.Convert(&meshv1.Node{Name: "owl", Target: "owl:8081"}, NodeOk))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "swan", Target: "swan:8081"}, NodeDead))
+	database.SetNode(data.Convert(&meshv1.Node{Name: "crow", Target: "crow:8081"}, NodeLeft))
+	old := data.Convert(&meshv1.Node{Name: "goose", Target: "goose:8081"}, NodeDead)
+	old.StateChangeTs = time.Now().Add(-2 * time.Hour).Unix()
+	database.SetNode(old)
+
+	name := "eagle"
+	s := &MeshServer{
+		log:          zap.NewNop().Sugar(),
+		data:         database,
+		name:         &name,
+		tombstoneTTL: time.Hour,
+		ownNode:      func() *meshv1.Node { return &meshv1.Node{Name: name} },
+	}
+	client := newTestClient(t, startTestServer(t, s))
+	res, err := client.PullState(context.Background(), &meshv1.StateDigest{IAmNode: &meshv1.Node{Name: "swan", Target: "swan:8081"}})
+	if err != nil {
+		t.Fatalf("pull state failed: %v", err)
+	}
+
+	nodes := []string{}
+	for _, node := range res.Nodes {
+		nodes = append(nodes, node.Name)
+	}
+	if diff := deep.Equal(nodes, []string{"owl"}); diff != nil {
+		t.Error(diff)
+	}
+	// the requester gets its own tombstone to refute it, expired tombstones are not sent
+	tombstones := map[string]int64{}
+	for _, tombstone := range res.Tombstones {
+		tombstones[tombstone.Node.Name] = tombstone.State
+	}
+	if diff := deep.Equal(tombstones, map[string]int64{"swan": NodeDead, "crow": NodeLeft}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_InvalidRequests(t *testing.T) {
+	database, err := data.NewMemDB(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("could not create db: %v", err)
+	}
+	name := "eagle"
+	s := &MeshServer{
+		log:               zap.NewNop().Sugar(),
+		data:              database,
+		name:              &name,
+		ownNode:           func() *meshv1.Node { return &meshv1.Node{Name: name, Target: "eagle:8081"} },
+		newNodeDiscovered: make(chan NodeDiscovered, 4),
+		nodeStateUpdate:   make(chan NodeStateUpdate, 4),
+	}
+	client := newTestClient(t, startTestServer(t, s))
+	owl := &meshv1.Node{Name: "owl", Target: "owl:8081"}
+
+	tests := []struct {
+		name    string
+		request func() error
+	}{
+		{
+			name: "node state update without node",
+			request: func() error {
+				_, err := client.NodeStateUpdate(context.Background(), &meshv1.NodeStateUpdateRequest{IAmNode: owl, State: NodeDead})
+				return err
+			},
+		},
+		{
+			name: "node state update without requesting node",
+			request: func() error {
+				_, err := client.NodeStateUpdate(context.Background(), &meshv1.NodeStateUpdateRequest{Node: owl, State: NodeDead})
+				return err
+			},
+		},
+		{
+			name: "node discovery without new node",
+			request: func() error {
+				_, err := client.NodeDiscovery(context.Background(), &meshv1.NodeDiscoveryRequest{IAmNode: owl})
+				return err
+			},
+		},
+		{
+			name: "node discovery of node without name",
+			request: func() error {
+				_, err := client.NodeDiscovery(context.Background(), &meshv1.NodeDiscoveryRequest{NewNode: &meshv1.Node{Target: "swan:8081"}, IAmNode: owl})
+				return err
+			},
+		},
+		{
+			name: "pull state without requesting node",
+			request: func() error {
+				_, err := client.PullState(context.Background(), &meshv1.StateDigest{})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.request()); code != codes.InvalidArgument {
+				t.Errorf("the status code (%v) is not as expected: %v", code, codes.InvalidArgument)
+			}
+		})
+	}
+	if len(s.newNodeDiscovered) != 0 || len(s.nodeStateUpdate) != 0 {
+		t.Error("an invalid request was handled")
+	}
+}
+
+func Test_LeaveMesh(t *testing.T) {
+	received := make(chan NodeStateUpdate, 4)
+	client := newTestClient(t, startTestServer(t, &MeshServer{log: zap.NewNop().Sugar(), nodeStateUpdate: received}))
+
+	tests := []struct {
+		name     string
+		node     *meshv1.Node
+		expected codes.Code
+	}{
+		{name: "node without name", node: &meshv1.Node{Target: "127.0.0.1:8081"}, expected: codes.InvalidArgument},
+		{name: "node without target", node: &meshv1.Node{Name: "owl"}, expected: codes.InvalidArgument},
+		{name: "another node", node: &meshv1.Node{Name: "swan", Target: "192.0.2.1:8081"}, expected: codes.PermissionDenied},
+		{name: "leaving node", node: &meshv1.Node{Name: "owl", Target: "127.0.0.1:8081"}, expected: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.LeaveMesh(context.Background(), tt.node)
+			if code := status.Code(err); code != tt.expected {
+				t.Errorf("the status code (%v) is not as expected: %v", code, tt.expected)
+			}
+		})
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("the amount of leave requests (%v) is not as expected: 1", len(received))
+	}
+	if update := <-received; update.Node.Name != "owl" || update.State != NodeLeft {
+		t.Errorf("the leave request is not as expected: %+v", update)
+	}
+}
@@ -23,6 +23,8 @@ package metric
 
 import (
 	"net/http"
+	"regexp"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/telekom/canary-bot/data"
@@ -31,19 +33,37 @@ import (
 //go:generate moq -out metric_test_moq.go . Metrics
 type Metrics interface {
 	GetRegistry() *prometheus.Registry
-	Handler(data data.Database, h http.Handler) http.Handler
+	Handler(data data.Store, h http.Handler) http.Handler
 	GetNodes() prometheus.Gauge
+	GetNodeRemoved() *prometheus.CounterVec
 	GetRtt() *prometheus.HistogramVec
+	GetSample() *prometheus.GaugeVec
+	GetSampleAge() *prometheus.GaugeVec
 }
 
+// invalidLabelChars matches characters not allowed in a prometheus label name
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
 type PrometheusMetrics struct {
-	registry *prometheus.Registry
-	nodes    prometheus.Gauge
-	rtt      *prometheus.HistogramVec
+	registry    *prometheus.Registry
+	nodes       prometheus.Gauge
+	nodeRemoved *prometheus.CounterVec
+	rtt         *prometheus.HistogramVec
+	sample      *prometheus.GaugeVec
+	sampleAge   *prometheus.GaugeVec
 }
 
-// InitMetrics initializes the metrics and returns the PrometheusMetrics
-func InitMetrics() *PrometheusMetrics {
+// InitMetrics initializes the metrics and returns the PrometheusMetrics.
+// For every node label key a from_<key> and to_<key> label is added to the rtt and sample metric.
+func InitMetrics(nodeLabelKeys ...string) *PrometheusMetrics {
+	sampleLabels := []string{"type", "to"}
+	for _, key := range nodeLabelKeys {
+		sampleLabels = append(sampleLabels, "from_"+sanitizeLabelName(key))
+	}
+	for _, key := range nodeLabelKeys {
+		sampleLabels = append(sampleLabels, "to_"+sanitizeLabelName(key))
+	}
+
 	m := &PrometheusMetrics{
 		registry: prometheus.NewRegistry(),
 		rtt: prometheus.NewHistogramVec(
@@ -51,43 +71,113 @@ func InitMetrics() *PrometheusMetrics {
 				Name: "rtt",
 				Help: "Round-trip-time to a mesh node",
 			},
-			[]string{"type", "to"},
+			sampleLabels,
+		),
+		sample: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sample",
+				Help: "Last measured value of a probe sample, durations in nanoseconds",
+			},
+			sampleLabels,
+		),
+		sampleAge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sample_age_max_seconds",
+				Help: "Age of the oldest sample per node pair",
+			},
+			[]string{"from", "to"},
 		),
 		nodes: prometheus.NewGauge(prometheus.GaugeOpts{
 			Name: "node_count",
 			Help: "Total number of nodes",
 		}),
+		nodeRemoved: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "node_removed_total",
+				Help: "Number of nodes removed from the mesh by reason (left, dead)",
+			},
+			[]string{"reason", "node"},
+		),
 	}
 
 	// register metrics
 	m.registry.MustRegister(
 		m.rtt,
 		m.nodes,
+		m.nodeRemoved,
+		m.sample,
+		m.sampleAge,
 	)
 
 	return m
 }
 
+// sanitizeLabelName replaces all characters not allowed in a prometheus label name
+func sanitizeLabelName(name string) string {
+	return invalidLabelChars.ReplaceAllString(name, "_")
+}
+
 // GetRegistry returns the registry to register prometheus metrics
 func (m *PrometheusMetrics) GetRegistry() *prometheus.Registry {
 	return m.registry
 }
 
 // Handler is a middleware to collect metrics
-func (m *PrometheusMetrics) Handler(data data.Database, h http.Handler) http.Handler {
+func (m *PrometheusMetrics) Handler(store data.Store, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// set node count
-		m.nodes.Set(float64(len(data.GetNodeList())))
+		// set node count, dead and left nodes are not in the mesh
+		count := 0
+		for _, node := range store.GetNodeList() {
+			if node.State != data.NodeStateDead && node.State != data.NodeStateLeft {
+				count++
+			}
+		}
+		m.nodes.Set(float64(count))
+		// set age of the oldest sample per node pair
+		m.setSampleAge(store.GetSampleList())
 		h.ServeHTTP(w, r)
 	})
 }
 
+// setSampleAge sets the age of the oldest sample per node pair
+func (m *PrometheusMetrics) setSampleAge(samples []*data.Sample) {
+	now := time.Now().Unix()
+	oldest := map[[2]string]int64{}
+	for _, sample := range samples {
+		pair := [2]string{sample.From, sample.To}
+		if age, exists := oldest[pair]; !exists || now-sample.Ts > age {
+			oldest[pair] = now - sample.Ts
+		}
+	}
+
+	// pairs without samples are removed
+	m.sampleAge.Reset()
+	for pair, age := range oldest {
+		m.sampleAge.WithLabelValues(pair[0], pair[1]).Set(float64(age))
+	}
+}
+
 // GetNodes returns the node count metric
 func (m *PrometheusMetrics) GetNodes() prometheus.Gauge {
 	return m.nodes
 }
 
+// GetNodeRemoved returns the removed nodes metric
+func (m *PrometheusMetrics) GetNodeRemoved() *prometheus.CounterVec {
+	return m.nodeRemoved
+}
+
 // GetRtt returns the rtt metric
 func (m *PrometheusMetrics) GetRtt() *prometheus.HistogramVec {
 	return m.rtt
 }
+
+// GetSample returns the sample metric
+func (m *PrometheusMetrics) GetSample() *prometheus.GaugeVec {
+	return m.sample
+}
+
+// GetSampleAge returns the sample age metric
+func (m *PrometheusMetrics) GetSampleAge() *prometheus.GaugeVec {
+	return m.sampleAge
+}
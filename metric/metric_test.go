@@ -25,7 +25,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/telekom/canary-bot/data"
 	"go.uber.org/zap"
 )
@@ -38,6 +41,42 @@ func TestInitMetric(t *testing.T) {
 	}
 }
 
+func TestInitMetricWithNodeLabels(t *testing.T) {
+	m := InitMetrics("zone", "topology.kubernetes.io/region")
+	_, err := m.GetRtt().GetMetricWith(prometheus.Labels{
+		"type":                               "rtt_total",
+		"to":                                 "goose",
+		"from_zone":                          "eu-1",
+		"from_topology_kubernetes_io_region": "eu",
+		"to_zone":                            "eu-2",
+		"to_topology_kubernetes_io_region":   "eu",
+	})
+	if err != nil {
+		t.Errorf("node labels are not part of the rtt metric: %v", err)
+	}
+}
+
+func Test_sanitizeLabelName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "valid name", input: "zone", expected: "zone"},
+		{name: "kubernetes label", input: "topology.kubernetes.io/zone", expected: "topology_kubernetes_io_zone"},
+		{name: "dash", input: "my-cluster", expected: "my_cluster"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sanitizeLabelName(tt.input)
+			if result != tt.expected {
+				t.Errorf("The result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetNodes(t *testing.T) {
 	m := InitMetrics()
 	nodes := m.GetNodes()
@@ -46,6 +85,14 @@ func TestGetNodes(t *testing.T) {
 	}
 }
 
+func TestGetNodeRemoved(t *testing.T) {
+	m := InitMetrics()
+	nodeRemoved := m.GetNodeRemoved()
+	if nodeRemoved == nil {
+		t.Error("nodeRemoved is nil")
+	}
+}
+
 func TestGetRtt(t *testing.T) {
 	m := InitMetrics()
 	rtt := m.GetRtt()
@@ -54,6 +101,58 @@ func TestGetRtt(t *testing.T) {
 	}
 }
 
+func TestGetSample(t *testing.T) {
+	m := InitMetrics()
+	sample := m.GetSample()
+	if sample == nil {
+		t.Error("sample is nil")
+	}
+}
+
+func TestGetSampleAge(t *testing.T) {
+	m := InitMetrics()
+	sampleAge := m.GetSampleAge()
+	if sampleAge == nil {
+		t.Error("sampleAge is nil")
+	}
+}
+
+func Test_setSampleAge(t *testing.T) {
+	now := time.Now().Unix()
+	m := InitMetrics()
+	m.setSampleAge([]*data.Sample{
+		{From: "owl", To: "swan", Key: data.RttTotal, Ts: now - 10},
+		{From: "owl", To: "swan", Key: data.RttRequest, Ts: now - 30},
+		{From: "swan", To: "owl", Key: data.RttTotal, Ts: now},
+	})
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected float64
+	}{
+		{name: "oldest sample of pair", from: "owl", to: "swan", expected: 30},
+		{name: "new sample", from: "swan", to: "owl", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := testutil.ToFloat64(m.GetSampleAge().WithLabelValues(tt.from, tt.to))
+			// the clock could tick during the test
+			if result < tt.expected || result > tt.expected+1 {
+				t.Errorf("The result (%v) is not as expected: %v", result, tt.expected)
+			}
+		})
+	}
+
+	// pairs without samples are removed
+	m.setSampleAge(nil)
+	if count := testutil.CollectAndCount(m.GetSampleAge()); count != 0 {
+		t.Errorf("The amount of pairs (%v) is not as expected: 0", count)
+	}
+}
+
 func TestHandler(t *testing.T) {
 	m := InitMetrics()
 	logger, err := zap.NewDevelopment()
@@ -79,3 +178,28 @@ func TestHandler(t *testing.T) {
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
 }
+
+func TestHandlerWithStore(t *testing.T) {
+	m := InitMetrics()
+	store := &data.StoreMock{
+		GetNodeListFunc: func() []*data.Node {
+			return []*data.Node{{Name: "owl"}, {Name: "swan"}, {Name: "crow", State: data.NodeStateLeft}, {Name: "raven", State: data.NodeStateDead}}
+		},
+		GetSampleListFunc: func() []*data.Sample {
+			return []*data.Sample{{From: "owl", To: "swan", Ts: time.Now().Add(-time.Minute).Unix()}}
+		},
+	}
+
+	handler := m.Handler(store, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "http://example.com/metrics", nil))
+
+	if len(store.GetNodeListCalls()) != 1 || len(store.GetSampleListCalls()) != 1 {
+		t.Errorf("the store was not called once per request")
+	}
+	if nodes := testutil.ToFloat64(m.GetNodes()); nodes != 2 {
+		t.Errorf("the node count (%v) is not as expected: 2", nodes)
+	}
+	if age := testutil.ToFloat64(m.GetSampleAge().WithLabelValues("owl", "swan")); age < 60 {
+		t.Errorf("the sample age (%v) is not as expected: >= 60", age)
+	}
+}
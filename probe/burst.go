@@ -0,0 +1,91 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"time"
+)
+
+// BurstKeys are the sample keys of a burst measurement
+type BurstKeys struct {
+	Loss   int64
+	Jitter int64
+	Min    int64
+	Avg    int64
+	Max    int64
+}
+
+// BurstSamples calculates the loss in percent, the jitter and the min/avg/max RTT
+// of a burst of requests. The RTTs hold the answered requests in the order they were sent.
+// The jitter is the mean absolute difference of consecutive RTTs.
+func BurstSamples(keys BurstKeys, rtts []time.Duration, sent int) []Sample {
+	if sent == 0 {
+		return []Sample{
+			{Key: keys.Loss, Failed: true},
+			{Key: keys.Jitter, Failed: true},
+			{Key: keys.Min, Failed: true},
+			{Key: keys.Avg, Failed: true},
+			{Key: keys.Max, Failed: true},
+		}
+	}
+
+	loss := Sample{Key: keys.Loss, Value: float64(sent-len(rtts)) / float64(sent) * 100}
+	if len(rtts) == 0 {
+		return []Sample{
+			loss,
+			{Key: keys.Jitter, Failed: true},
+			{Key: keys.Min, Failed: true},
+			{Key: keys.Avg, Failed: true},
+			{Key: keys.Max, Failed: true},
+		}
+	}
+
+	min, max, sum := rtts[0], rtts[0], time.Duration(0)
+	var jitter time.Duration
+	for i, rtt := range rtts {
+		sum += rtt
+		if rtt < min {
+			min = rtt
+		}
+		if rtt > max {
+			max = rtt
+		}
+		if i > 0 {
+			diff := rtt - rtts[i-1]
+			if diff < 0 {
+				diff = -diff
+			}
+			jitter += diff
+		}
+	}
+	if len(rtts) > 1 {
+		jitter /= time.Duration(len(rtts) - 1)
+	}
+
+	return []Sample{
+		loss,
+		{Key: keys.Jitter, Value: float64(jitter.Nanoseconds())},
+		{Key: keys.Min, Value: float64(min.Nanoseconds())},
+		{Key: keys.Avg, Value: float64((sum / time.Duration(len(rtts))).Nanoseconds())},
+		{Key: keys.Max, Value: float64(max.Nanoseconds())},
+	}
+}
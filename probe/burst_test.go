@@ -0,0 +1,95 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func Test_BurstSamples(t *testing.T) {
+	keys := BurstKeys{Loss: 1, Jitter: 2, Min: 3, Avg: 4, Max: 5}
+	ms := time.Millisecond
+
+	tests := []struct {
+		name string
+		rtts []time.Duration
+		sent int
+		want []Sample
+	}{
+		{
+			name: "Nothing sent",
+			want: []Sample{
+				{Key: 1, Failed: true},
+				{Key: 2, Failed: true},
+				{Key: 3, Failed: true},
+				{Key: 4, Failed: true},
+				{Key: 5, Failed: true},
+			},
+		},
+		{
+			name: "All lost",
+			sent: 4,
+			want: []Sample{
+				{Key: 1, Value: 100},
+				{Key: 2, Failed: true},
+				{Key: 3, Failed: true},
+				{Key: 4, Failed: true},
+				{Key: 5, Failed: true},
+			},
+		},
+		{
+			name: "Single answer",
+			rtts: []time.Duration{10 * ms},
+			sent: 1,
+			want: []Sample{
+				{Key: 1, Value: 0},
+				{Key: 2, Value: 0},
+				{Key: 3, Value: float64(10 * ms)},
+				{Key: 4, Value: float64(10 * ms)},
+				{Key: 5, Value: float64(10 * ms)},
+			},
+		},
+		{
+			name: "Partial loss",
+			rtts: []time.Duration{10 * ms, 20 * ms, 15 * ms},
+			sent: 4,
+			want: []Sample{
+				{Key: 1, Value: 25},
+				{Key: 2, Value: float64(7500 * time.Microsecond)},
+				{Key: 3, Value: float64(10 * ms)},
+				{Key: 4, Value: float64(15 * ms)},
+				{Key: 5, Value: float64(20 * ms)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(BurstSamples(keys, tt.rtts, tt.sent), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+// dnsPort is used for resolver addresses without port
+const dnsPort = "53"
+
+// DNSProbe resolves hostnames with the system resolver and optional explicit resolvers
+type DNSProbe struct {
+	targets   []Target
+	resolvers map[string]*net.Resolver
+	expect    map[string][]string
+	interval  time.Duration
+	timeout   time.Duration
+}
+
+// NewDNSProbe creates a probe resolving the hostnames against the system resolver
+// and every resolver address. Expect maps a hostname to the expected answers,
+// separated by "|" e.g. example.com=192.0.2.1|192.0.2.2
+func NewDNSProbe(hostnames []string, resolvers []string, expect map[string]string, interval time.Duration, timeout time.Duration) (*DNSProbe, error) {
+	p := &DNSProbe{
+		resolvers: map[string]*net.Resolver{},
+		expect:    map[string][]string{},
+		interval:  interval,
+		timeout:   timeout,
+	}
+
+	for hostname, answers := range expect {
+		p.expect[hostname] = sortedAnswers(strings.Split(answers, "|"))
+	}
+
+	for _, hostname := range hostnames {
+		if hostname == "" {
+			return nil, errors.New("empty hostname for DNS probe")
+		}
+		// system resolver
+		p.targets = append(p.targets, Target{Name: hostname, Address: hostname})
+		p.resolvers[hostname] = net.DefaultResolver
+
+		for _, resolver := range resolvers {
+			address := resolver
+			if _, _, err := net.SplitHostPort(resolver); err != nil {
+				address = net.JoinHostPort(resolver, dnsPort)
+			}
+			name := hostname + "@" + resolver
+			p.targets = append(p.targets, Target{Name: name, Address: hostname})
+			p.resolvers[name] = newResolver(address)
+		}
+	}
+	return p, nil
+}
+
+// newResolver creates a resolver sending all queries to the address
+func newResolver(address string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+func (p *DNSProbe) Name() string {
+	return "dns"
+}
+
+func (p *DNSProbe) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *DNSProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *DNSProbe) Targets() []Target {
+	return p.targets
+}
+
+// Run resolves the hostname and measures the resolution time, the answer count
+// and if the answers match the expected ones
+func (p *DNSProbe) Run(ctx context.Context, target Target) []Sample {
+	resolver, ok := p.resolvers[target.Name]
+	if !ok {
+		resolver = net.DefaultResolver
+	}
+	expected, hasExpected := p.expect[target.Address]
+
+	start := time.Now()
+	answers, err := resolver.LookupHost(ctx, target.Address)
+	duration := time.Since(start)
+
+	var samples []Sample
+	if err != nil {
+		samples = []Sample{
+			{Key: data.DnsDuration, Failed: true},
+			{Key: data.DnsAnswers, Value: 0},
+		}
+		if hasExpected {
+			samples = append(samples, Sample{Key: data.DnsMatch, Value: 0})
+		}
+		return samples
+	}
+
+	samples = []Sample{
+		{Key: data.DnsDuration, Value: float64(duration.Nanoseconds())},
+		{Key: data.DnsAnswers, Value: float64(len(answers))},
+	}
+	if hasExpected {
+		match := 0.0
+		if strings.Join(sortedAnswers(answers), "|") == strings.Join(expected, "|") {
+			match = 1
+		}
+		samples = append(samples, Sample{Key: data.DnsMatch, Value: match})
+	}
+	return samples
+}
+
+// sortedAnswers returns the trimmed answers in sorted order
+func sortedAnswers(answers []string) []string {
+	sorted := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		if answer = strings.TrimSpace(answer); answer != "" {
+			sorted = append(sorted, answer)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
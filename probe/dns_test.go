@@ -0,0 +1,125 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+)
+
+func Test_NewDNSProbe(t *testing.T) {
+	p, err := NewDNSProbe([]string{"owl.com", "swan.com"}, []string{"192.0.2.53", "192.0.2.54:5353"}, nil, time.Second, time.Second)
+	if err != nil {
+		t.Fatalf("could not create probe: %v", err)
+	}
+
+	var names []string
+	for _, target := range p.Targets() {
+		names = append(names, target.Name)
+	}
+	want := []string{
+		"owl.com", "owl.com@192.0.2.53", "owl.com@192.0.2.54:5353",
+		"swan.com", "swan.com@192.0.2.53", "swan.com@192.0.2.54:5353",
+	}
+	if diff := deep.Equal(names, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_DNSProbeRun(t *testing.T) {
+	// reserve a port without a DNS server
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	deadResolver := conn.LocalAddr().String()
+	conn.Close()
+
+	tests := []struct {
+		name      string
+		hostname  string
+		resolvers []string
+		expect    map[string]string
+		target    int
+		want      map[int64]float64
+		wantFail  bool
+	}{
+		{
+			name:     "IP address",
+			hostname: "192.0.2.1",
+			want:     map[int64]float64{data.DnsAnswers: 1},
+		},
+		{
+			name:     "Expected answer",
+			hostname: "192.0.2.1",
+			expect:   map[string]string{"192.0.2.1": "192.0.2.1"},
+			want:     map[int64]float64{data.DnsAnswers: 1, data.DnsMatch: 1},
+		},
+		{
+			name:     "Unexpected answer",
+			hostname: "192.0.2.1",
+			expect:   map[string]string{"192.0.2.1": "192.0.2.1|192.0.2.2"},
+			want:     map[int64]float64{data.DnsAnswers: 1, data.DnsMatch: 0},
+		},
+		{
+			name:      "Resolver not reachable",
+			hostname:  "owl.invalid",
+			resolvers: []string{deadResolver},
+			expect:    map[string]string{"owl.invalid": "192.0.2.1"},
+			target:    1,
+			want:      map[int64]float64{data.DnsAnswers: 0, data.DnsMatch: 0},
+			wantFail:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewDNSProbe([]string{tt.hostname}, tt.resolvers, tt.expect, time.Second, time.Second)
+			if err != nil {
+				t.Fatalf("could not create probe: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, p.Targets()[tt.target])
+
+			got := map[int64]float64{}
+			for _, sample := range samples {
+				if sample.Key == data.DnsDuration {
+					if sample.Failed != tt.wantFail {
+						t.Errorf("duration sample failed = %v, expected %v", sample.Failed, tt.wantFail)
+					}
+					continue
+				}
+				got[sample.Key] = sample.Value
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
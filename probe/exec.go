@@ -0,0 +1,115 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+// execWaitDelay is the time to wait for the output after the command was killed
+const execWaitDelay = time.Second
+
+// ExecProbe runs local commands as custom checks
+type ExecProbe struct {
+	targets  []Target
+	env      []string
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewExecProbe creates a probe running the commands with sh -c.
+// Commands map a name to the command, the environment is added to the one of the bot.
+func NewExecProbe(commands map[string]string, env map[string]string, interval time.Duration, timeout time.Duration) (*ExecProbe, error) {
+	p := &ExecProbe{
+		env:      os.Environ(),
+		interval: interval,
+		timeout:  timeout,
+	}
+
+	for name, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			return nil, errors.New("empty command for exec probe: " + name)
+		}
+		p.targets = append(p.targets, Target{Name: name, Address: command})
+	}
+	for key, value := range env {
+		p.env = append(p.env, key+"="+value)
+	}
+	return p, nil
+}
+
+func (p *ExecProbe) Name() string {
+	return "exec"
+}
+
+func (p *ExecProbe) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *ExecProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *ExecProbe) Targets() []Target {
+	return p.targets
+}
+
+// Run executes the command and measures the exit code, the duration and
+// the numeric value printed to stdout. The exit code is -1 if the command was killed.
+func (p *ExecProbe) Run(ctx context.Context, target Target) []Sample {
+	var stdout bytes.Buffer
+	/* #nosec G204 */
+	cmd := exec.CommandContext(ctx, "sh", "-c", target.Address)
+	cmd.Env = p.env
+	cmd.Stdout = &stdout
+	cmd.WaitDelay = execWaitDelay
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return []Sample{
+			{Key: data.ExecExitCode, Failed: true},
+			{Key: data.ExecDuration, Failed: true},
+		}
+	}
+
+	samples := []Sample{
+		{Key: data.ExecExitCode, Value: float64(cmd.ProcessState.ExitCode())},
+		{Key: data.ExecDuration, Value: float64(duration.Nanoseconds())},
+	}
+	if value, err := strconv.ParseFloat(strings.TrimSpace(stdout.String()), 64); err == nil {
+		samples = append(samples, Sample{Key: data.ExecValue, Value: value})
+	}
+	return samples
+}
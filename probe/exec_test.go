@@ -0,0 +1,102 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+)
+
+func Test_ExecProbeRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		timeout time.Duration
+		want    map[int64]float64
+	}{
+		{
+			name:    "Success",
+			command: "true",
+			want:    map[int64]float64{data.ExecExitCode: 0},
+		},
+		{
+			name:    "Exit code",
+			command: "exit 3",
+			want:    map[int64]float64{data.ExecExitCode: 3},
+		},
+		{
+			name:    "Numeric output",
+			command: "echo 42.5",
+			want:    map[int64]float64{data.ExecExitCode: 0, data.ExecValue: 42.5},
+		},
+		{
+			name:    "Environment",
+			command: "echo $OWL",
+			want:    map[int64]float64{data.ExecExitCode: 0, data.ExecValue: 7},
+		},
+		{
+			name:    "Non-numeric output",
+			command: "echo owl",
+			want:    map[int64]float64{data.ExecExitCode: 0},
+		},
+		{
+			name:    "Timeout",
+			command: "sleep 5",
+			timeout: 100 * time.Millisecond,
+			want:    map[int64]float64{data.ExecExitCode: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := time.Second
+			if tt.timeout > 0 {
+				timeout = tt.timeout
+			}
+			p, err := NewExecProbe(map[string]string{"check": tt.command}, map[string]string{"OWL": "7"}, time.Second, timeout)
+			if err != nil {
+				t.Fatalf("could not create probe: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, p.Targets()[0])
+
+			got := map[int64]float64{}
+			for _, sample := range samples {
+				if sample.Failed {
+					t.Errorf("sample %v failed", data.SampleName[sample.Key])
+				}
+				if sample.Key != data.ExecDuration {
+					got[sample.Key] = sample.Value
+				}
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// GRPCHealthProbe calls the standard gRPC health service of targets
+type GRPCHealthProbe struct {
+	targets  []Target
+	services map[string]string
+	creds    credentials.TransportCredentials
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewGRPCHealthProbe creates a probe checking the health of the targets with the credentials.
+// Format: [IP|ADDRESS]:PORT[/SERVICE], the overall health is checked without service name
+func NewGRPCHealthProbe(targets []string, creds credentials.TransportCredentials, interval time.Duration, timeout time.Duration) (*GRPCHealthProbe, error) {
+	p := &GRPCHealthProbe{
+		services: map[string]string{},
+		creds:    creds,
+		interval: interval,
+		timeout:  timeout,
+	}
+
+	for _, target := range targets {
+		address, service, _ := strings.Cut(target, "/")
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, err
+		}
+		p.targets = append(p.targets, Target{Name: target, Address: address})
+		p.services[target] = service
+	}
+	return p, nil
+}
+
+func (p *GRPCHealthProbe) Name() string {
+	return "grpc-health"
+}
+
+func (p *GRPCHealthProbe) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *GRPCHealthProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *GRPCHealthProbe) Targets() []Target {
+	return p.targets
+}
+
+// Run checks the health of the target and measures the serving status and the latency.
+// The status is UNKNOWN (0) if the check failed.
+func (p *GRPCHealthProbe) Run(ctx context.Context, target Target) []Sample {
+	failed := []Sample{
+		{Key: data.GrpcHealthStatus, Value: float64(healthpb.HealthCheckResponse_UNKNOWN)},
+		{Key: data.GrpcHealthLatency, Failed: true},
+	}
+
+	conn, err := grpc.DialContext(ctx, target.Address, grpc.WithTransportCredentials(p.creds), grpc.WithBlock())
+	if err != nil {
+		return failed
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: p.services[target.Name]})
+	if err != nil {
+		return failed
+	}
+	latency := time.Since(start)
+
+	return []Sample{
+		{Key: data.GrpcHealthStatus, Value: float64(res.Status)},
+		{Key: data.GrpcHealthLatency, Value: float64(latency.Nanoseconds())},
+	}
+}
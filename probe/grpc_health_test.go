@@ -0,0 +1,106 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func Test_GRPCHealthProbeRun(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("owl", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("swan", healthpb.HealthCheckResponse_NOT_SERVING)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	address := listener.Addr().String()
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus healthpb.HealthCheckResponse_ServingStatus
+		wantFail   bool
+	}{
+		{
+			name:       "Overall health",
+			target:     address,
+			wantStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "Serving service",
+			target:     address + "/owl",
+			wantStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "Not serving service",
+			target:     address + "/swan",
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:       "Unknown service",
+			target:     address + "/eagle",
+			wantStatus: healthpb.HealthCheckResponse_UNKNOWN,
+			wantFail:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewGRPCHealthProbe([]string{tt.target}, insecure.NewCredentials(), time.Second, time.Second)
+			if err != nil {
+				t.Fatalf("could not create probe: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, p.Targets()[0])
+
+			for _, sample := range samples {
+				switch sample.Key {
+				case data.GrpcHealthStatus:
+					if sample.Value != float64(tt.wantStatus) {
+						t.Errorf("status %v is not as expected: %v", sample.Value, float64(tt.wantStatus))
+					}
+				case data.GrpcHealthLatency:
+					if sample.Failed != tt.wantFail {
+						t.Errorf("latency sample failed = %v, expected %v", sample.Failed, tt.wantFail)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+// maxBodySize is the maximum amount of bytes read for the body match
+const maxBodySize = 1 << 20
+
+// HTTPProbe requests external HTTP(S) endpoints
+type HTTPProbe struct {
+	targets   []Target
+	bodyMatch *regexp.Regexp
+	interval  time.Duration
+	timeout   time.Duration
+	client    *http.Client
+}
+
+// NewHTTPProbe creates a probe requesting the URLs.
+// If bodyMatch is set, the response body will be matched against the regular expression.
+func NewHTTPProbe(urls []string, bodyMatch string, interval time.Duration, timeout time.Duration) (*HTTPProbe, error) {
+	p := &HTTPProbe{
+		interval: interval,
+		timeout:  timeout,
+		client: &http.Client{
+			// measure the endpoint itself, not the redirect target
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+
+	for _, rawUrl := range urls {
+		u, err := url.Parse(rawUrl)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, errors.New("unsupported scheme of HTTP target: " + rawUrl)
+		}
+		p.targets = append(p.targets, Target{Name: rawUrl, Address: rawUrl})
+	}
+
+	if bodyMatch != "" {
+		re, err := regexp.Compile(bodyMatch)
+		if err != nil {
+			return nil, err
+		}
+		p.bodyMatch = re
+	}
+	return p, nil
+}
+
+func (p *HTTPProbe) Name() string {
+	return "http"
+}
+
+func (p *HTTPProbe) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *HTTPProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *HTTPProbe) Targets() []Target {
+	return p.targets
+}
+
+// Run requests the target and measures the status code, the latency
+// and if the body matches
+func (p *HTTPProbe) Run(ctx context.Context, target Target) []Sample {
+	failed := []Sample{
+		{Key: data.HttpStatus, Failed: true},
+		{Key: data.HttpLatency, Failed: true},
+	}
+	if p.bodyMatch != nil {
+		failed = append(failed, Sample{Key: data.HttpBodyMatch, Failed: true})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.Address, nil)
+	if err != nil {
+		return failed
+	}
+
+	start := time.Now()
+	res, err := p.client.Do(req)
+	if err != nil {
+		return failed
+	}
+	defer res.Body.Close()
+
+	samples := []Sample{
+		{Key: data.HttpStatus, Value: float64(res.StatusCode)},
+	}
+
+	if p.bodyMatch == nil {
+		// latency until the response header was received
+		return append(samples, Sample{Key: data.HttpLatency, Value: float64(time.Since(start).Nanoseconds())})
+	}
+
+	// latency until the response body was received
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize))
+	if err != nil {
+		return append(samples, failed[1:]...)
+	}
+	samples = append(samples, Sample{Key: data.HttpLatency, Value: float64(time.Since(start).Nanoseconds())})
+
+	match := 0.0
+	if p.bodyMatch.Match(body) {
+		match = 1
+	}
+	return append(samples, Sample{Key: data.HttpBodyMatch, Value: match})
+}
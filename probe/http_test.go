@@ -0,0 +1,153 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/telekom/canary-bot/data"
+)
+
+func Test_NewHTTPProbe(t *testing.T) {
+	tests := []struct {
+		name      string
+		urls      []string
+		bodyMatch string
+		wantErr   bool
+	}{
+		{
+			name: "Valid URLs",
+			urls: []string{"http://owl.com", "https://owl.com/health"},
+		},
+		{
+			name:    "Unsupported scheme",
+			urls:    []string{"ftp://owl.com"},
+			wantErr: true,
+		},
+		{
+			name:      "Invalid body match",
+			urls:      []string{"http://owl.com"},
+			bodyMatch: "(",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewHTTPProbe(tt.urls, tt.bodyMatch, time.Second, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(p.Targets()) != len(tt.urls) {
+				t.Errorf("the amount of targets (%v) is not as expected: %v", len(p.Targets()), len(tt.urls))
+			}
+		})
+	}
+}
+
+func Test_HTTPProbeRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("status: ok"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		url       string
+		bodyMatch string
+		want      map[int64]float64
+		wantFail  bool
+	}{
+		{
+			name: "Status OK",
+			url:  server.URL,
+			want: map[int64]float64{data.HttpStatus: 200},
+		},
+		{
+			name: "Status not found",
+			url:  server.URL + "/missing",
+			want: map[int64]float64{data.HttpStatus: 404},
+		},
+		{
+			name:      "Body matches",
+			url:       server.URL,
+			bodyMatch: "status: (ok|up)",
+			want:      map[int64]float64{data.HttpStatus: 200, data.HttpBodyMatch: 1},
+		},
+		{
+			name:      "Body does not match",
+			url:       server.URL,
+			bodyMatch: "status: down",
+			want:      map[int64]float64{data.HttpStatus: 200, data.HttpBodyMatch: 0},
+		},
+		{
+			name:     "Unreachable",
+			url:      "http://127.0.0.1:1",
+			wantFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewHTTPProbe([]string{tt.url}, tt.bodyMatch, time.Second, time.Second)
+			if err != nil {
+				t.Fatalf("could not create probe: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, p.Targets()[0])
+
+			got := map[int64]float64{}
+			for _, sample := range samples {
+				if sample.Failed != tt.wantFail {
+					t.Errorf("sample %v failed = %v, expected %v", data.SampleName[sample.Key], sample.Failed, tt.wantFail)
+				}
+				if sample.Key == data.HttpLatency {
+					if !sample.Failed && sample.Value <= 0 {
+						t.Errorf("latency %v is not positive", sample.Value)
+					}
+					continue
+				}
+				if !sample.Failed {
+					got[sample.Key] = sample.Value
+				}
+			}
+
+			if tt.wantFail {
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
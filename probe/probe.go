@@ -0,0 +1,114 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Probe measures samples from this node to a target.
+// Every registered probe will be scheduled independently by the mesh.
+type Probe interface {
+	// Name is the unique name of the probe
+	Name() string
+	// Interval between two runs of the probe
+	Interval() time.Duration
+	// Timeout of a single run, the context of Run will be canceled afterwards
+	Timeout() time.Duration
+	// Run measures the samples to the target
+	Run(ctx context.Context, target Target) []Sample
+}
+
+// TargetProvider can be implemented by a probe to run against its own targets.
+// Probes without own targets will run against a random healthy node of the mesh.
+type TargetProvider interface {
+	Targets() []Target
+}
+
+// PeerCycler can be implemented by a probe without own targets to measure every healthy node
+// once per probe cycle of the mesh instead of a random node per interval.
+type PeerCycler interface {
+	PeerCycle() bool
+}
+
+// Target is the destination of a probe
+type Target struct {
+	// Name is used as receiver of the samples
+	Name string
+	// Address of the target e.g. address:port, URL
+	Address string
+	// Labels of the target e.g. zone, region, cluster
+	Labels map[string]string
+}
+
+// Sample is a measurement of a probe
+type Sample struct {
+	// Key is the sample key, register its name with data.RegisterSampleName
+	Key int64
+	// Value is the measurement value, durations are measured in nanoseconds
+	Value float64
+	// Failed is set if the measurement failed, the value will be ignored
+	Failed bool
+}
+
+// Registry holds the probes of a mesh
+type Registry struct {
+	mu     sync.Mutex
+	probes map[string]Probe
+}
+
+// NewRegistry creates an empty probe registry
+func NewRegistry() *Registry {
+	return &Registry{probes: map[string]Probe{}}
+}
+
+// Register adds a probe to the registry.
+// The name of the probe has to be unique.
+func (r *Registry) Register(p Probe) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.probes[p.Name()]; exists {
+		return errors.New("probe already registered: " + p.Name())
+	}
+	r.probes[p.Name()] = p
+	return nil
+}
+
+// Probes returns all registered probes sorted by name
+func (r *Registry) Probes() []Probe {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	probes := make([]Probe, 0, len(r.probes))
+	for _, p := range r.probes {
+		probes = append(probes, p)
+	}
+	sort.Slice(probes, func(i, j int) bool {
+		return probes[i].Name() < probes[j].Name()
+	})
+	return probes
+}
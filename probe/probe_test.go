@@ -0,0 +1,67 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testProbe is a probe doing nothing
+type testProbe struct {
+	name string
+}
+
+func (p *testProbe) Name() string                                    { return p.name }
+func (p *testProbe) Interval() time.Duration                         { return time.Second }
+func (p *testProbe) Timeout() time.Duration                          { return time.Second }
+func (p *testProbe) Run(ctx context.Context, target Target) []Sample { return nil }
+
+func Test_Register(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(&testProbe{name: "owl"}); err != nil {
+		t.Errorf("could not register probe: %v", err)
+	}
+	if err := r.Register(&testProbe{name: "owl"}); err == nil {
+		t.Error("probe with the same name registered twice")
+	}
+}
+
+func Test_Probes(t *testing.T) {
+	r := NewRegistry()
+	for _, name := range []string{"swan", "eagle", "owl"} {
+		if err := r.Register(&testProbe{name: name}); err != nil {
+			t.Errorf("could not register probe: %v", err)
+		}
+	}
+
+	probes := r.Probes()
+	if len(probes) != 3 {
+		t.Fatalf("the amount of probes (%v) is not as expected: 3", len(probes))
+	}
+	for i, name := range []string{"eagle", "owl", "swan"} {
+		if probes[i].Name() != name {
+			t.Errorf("probe %v is %v, expected %v", i, probes[i].Name(), name)
+		}
+	}
+}
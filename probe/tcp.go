@@ -0,0 +1,91 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+// TCPProbe opens a TCP connection to host:port targets
+type TCPProbe struct {
+	targets  []Target
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewTCPProbe creates a probe connecting to the addresses.
+// Format: [IP|ADDRESS]:PORT
+func NewTCPProbe(addresses []string, interval time.Duration, timeout time.Duration) (*TCPProbe, error) {
+	p := &TCPProbe{
+		interval: interval,
+		timeout:  timeout,
+	}
+
+	for _, address := range addresses {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, err
+		}
+		p.targets = append(p.targets, Target{Name: address, Address: address})
+	}
+	return p, nil
+}
+
+func (p *TCPProbe) Name() string {
+	return "tcp"
+}
+
+func (p *TCPProbe) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *TCPProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *TCPProbe) Targets() []Target {
+	return p.targets
+}
+
+// Run connects to the target and measures the connect time and the success
+func (p *TCPProbe) Run(ctx context.Context, target Target) []Sample {
+	var dialer net.Dialer
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return []Sample{
+			{Key: data.TcpConnect, Failed: true},
+			{Key: data.TcpSuccess, Value: 0},
+		}
+	}
+	connect := time.Since(start)
+	conn.Close()
+
+	return []Sample{
+		{Key: data.TcpConnect, Value: float64(connect.Nanoseconds())},
+		{Key: data.TcpSuccess, Value: 1},
+	}
+}
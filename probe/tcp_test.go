@@ -0,0 +1,88 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+func Test_TCPProbeRun(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	defer listener.Close()
+
+	tests := []struct {
+		name        string
+		address     string
+		wantSuccess float64
+	}{
+		{
+			name:        "Reachable",
+			address:     listener.Addr().String(),
+			wantSuccess: 1,
+		},
+		{
+			name:        "Connection refused",
+			address:     "127.0.0.1:1",
+			wantSuccess: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewTCPProbe([]string{tt.address}, time.Second, time.Second)
+			if err != nil {
+				t.Fatalf("could not create probe: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, p.Targets()[0])
+
+			for _, sample := range samples {
+				switch sample.Key {
+				case data.TcpSuccess:
+					if sample.Value != tt.wantSuccess {
+						t.Errorf("success %v is not as expected: %v", sample.Value, tt.wantSuccess)
+					}
+				case data.TcpConnect:
+					if sample.Failed != (tt.wantSuccess == 0) {
+						t.Errorf("connect sample failed = %v, expected %v", sample.Failed, tt.wantSuccess == 0)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_NewTCPProbe(t *testing.T) {
+	if _, err := NewTCPProbe([]string{"owl.com"}, time.Second, time.Second); err == nil {
+		t.Error("address without port accepted")
+	}
+}
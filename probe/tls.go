@@ -0,0 +1,152 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+// tlsVersion maps the TLS version to the sample value
+var tlsVersion = map[uint16]float64{
+	tls.VersionTLS10: 1.0,
+	tls.VersionTLS11: 1.1,
+	tls.VersionTLS12: 1.2,
+	tls.VersionTLS13: 1.3,
+}
+
+// TLSProbe checks the certificates and the handshake of TLS endpoints
+type TLSProbe struct {
+	targets  []Target
+	rootCAs  *x509.CertPool
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewTLSProbe creates a probe connecting to the TLS endpoints.
+// The chain will be verified against the root CAs, the system pool is used if nil.
+// Format: [IP|ADDRESS]:PORT
+func NewTLSProbe(addresses []string, rootCAs *x509.CertPool, interval time.Duration, timeout time.Duration) (*TLSProbe, error) {
+	p := &TLSProbe{
+		rootCAs:  rootCAs,
+		interval: interval,
+		timeout:  timeout,
+	}
+
+	for _, address := range addresses {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, err
+		}
+		p.targets = append(p.targets, Target{Name: address, Address: address})
+	}
+	return p, nil
+}
+
+func (p *TLSProbe) Name() string {
+	return "tls"
+}
+
+func (p *TLSProbe) Interval() time.Duration {
+	return p.interval
+}
+
+func (p *TLSProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *TLSProbe) Targets() []Target {
+	return p.targets
+}
+
+// Run connects to the target and measures the handshake latency, the days until
+// the first certificate of the chain expires, the chain validity and the negotiated version
+func (p *TLSProbe) Run(ctx context.Context, target Target) []Sample {
+	failed := []Sample{
+		{Key: data.TlsHandshake, Failed: true},
+		{Key: data.TlsExpiryDays, Failed: true},
+		{Key: data.TlsChainValid, Value: 0},
+		{Key: data.TlsVersion, Failed: true},
+	}
+
+	host, _, err := net.SplitHostPort(target.Address)
+	if err != nil {
+		return failed
+	}
+
+	var dialer net.Dialer
+	rawConn, err := dialer.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return failed
+	}
+	defer rawConn.Close()
+
+	// the chain is verified afterwards to report expired or untrusted certificates as samples
+	/* #nosec G402 */
+	conn := tls.Client(rawConn, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS10,
+	})
+
+	start := time.Now()
+	if err = conn.HandshakeContext(ctx); err != nil {
+		return failed
+	}
+	handshake := time.Since(start)
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return failed
+	}
+
+	// first expiring certificate of the chain
+	expiry := state.PeerCertificates[0].NotAfter
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+		if cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+
+	valid := 0.0
+	_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         p.rootCAs,
+		Intermediates: intermediates,
+	})
+	if err == nil {
+		valid = 1
+	}
+
+	return []Sample{
+		{Key: data.TlsHandshake, Value: float64(handshake.Nanoseconds())},
+		{Key: data.TlsExpiryDays, Value: time.Until(expiry).Hours() / 24},
+		{Key: data.TlsChainValid, Value: valid},
+		{Key: data.TlsVersion, Value: tlsVersion[state.Version]},
+	}
+}
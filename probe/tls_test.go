@@ -0,0 +1,100 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+)
+
+func Test_TLSProbeRun(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(server.Certificate())
+
+	tests := []struct {
+		name      string
+		address   string
+		rootCAs   *x509.CertPool
+		wantValid float64
+		wantFail  bool
+	}{
+		{
+			name:      "Trusted certificate",
+			address:   server.Listener.Addr().String(),
+			rootCAs:   trusted,
+			wantValid: 1,
+		},
+		{
+			name:      "Untrusted certificate",
+			address:   server.Listener.Addr().String(),
+			rootCAs:   x509.NewCertPool(),
+			wantValid: 0,
+		},
+		{
+			name:     "Connection refused",
+			address:  "127.0.0.1:1",
+			wantFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewTLSProbe([]string{tt.address}, tt.rootCAs, time.Second, time.Second)
+			if err != nil {
+				t.Fatalf("could not create probe: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+			defer cancel()
+			samples := p.Run(ctx, p.Targets()[0])
+
+			for _, sample := range samples {
+				switch sample.Key {
+				case data.TlsChainValid:
+					if sample.Value != tt.wantValid {
+						t.Errorf("chain valid %v is not as expected: %v", sample.Value, tt.wantValid)
+					}
+				case data.TlsExpiryDays:
+					if !tt.wantFail && sample.Value <= 0 {
+						t.Errorf("days until expiry %v is not positive", sample.Value)
+					}
+				case data.TlsVersion:
+					if !tt.wantFail && sample.Value != 1.3 {
+						t.Errorf("version %v is not as expected: 1.3", sample.Value)
+					}
+				}
+				if sample.Key != data.TlsChainValid && sample.Failed != tt.wantFail {
+					t.Errorf("sample %v failed = %v, expected %v", data.SampleName[sample.Key], sample.Failed, tt.wantFail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+	"go.uber.org/zap"
+)
+
+// udpPacketSize is the size of a probe packet: 8 byte burst ID, 8 byte sequence number
+const udpPacketSize = 16
+
+// ServeUDPEcho sends every received packet back to its sender
+// until the connection is closed. A failed reply is logged and skipped.
+func ServeUDPEcho(conn net.PacketConn, log *zap.SugaredLogger) error {
+	buf := make([]byte, udpPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		if _, err = conn.WriteTo(buf[:n], addr); err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			log.Debugw("Could not send echo", "to", addr.String(), "error", err)
+		}
+	}
+}
+
+// UDPProbe sends a burst of packets to the UDP echo listener of a node
+// and measures the loss, jitter and min/avg/max RTT
+type UDPProbe struct {
+	port     int64
+	amount   int
+	spacing  time.Duration
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewUDPProbe creates a probe sending amount packets with spacing in between
+// to the UDP echo port of the node
+func NewUDPProbe(port int64, amount int, spacing time.Duration, interval time.Duration, timeout time.Duration) *UDPProbe {
+	return &UDPProbe{
+		port:     port,
+		amount:   amount,
+		spacing:  spacing,
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+func (p *UDPProbe) Name() string {
+	return "udp-loss"
+}
+
+func (p *UDPProbe) Interval() time.Duration {
+	return p.interval
+}
+
+// Timeout includes the duration of sending the burst
+func (p *UDPProbe) Timeout() time.Duration {
+	return time.Duration(p.amount)*p.spacing + p.timeout
+}
+
+// Run sends the burst to the host of the target and waits for the echoes
+// until all packets are answered or the context is done
+func (p *UDPProbe) Run(ctx context.Context, target Target) []Sample {
+	keys := BurstKeys{
+		Loss:   data.UdpLossPercent,
+		Jitter: data.UdpJitter,
+		Min:    data.UdpRttMin,
+		Avg:    data.UdpRttAvg,
+		Max:    data.UdpRttMax,
+	}
+
+	host, _, err := net.SplitHostPort(target.Address)
+	if err != nil {
+		host = target.Address
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", net.JoinHostPort(host, strconv.FormatInt(p.port, 10)))
+	if err != nil {
+		return BurstSamples(keys, nil, 0)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetReadDeadline(deadline)
+	}
+
+	// the burst ID filters delayed echoes of former bursts
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	var mu sync.Mutex
+	sent := make([]time.Time, p.amount)
+	rtts := make([]time.Duration, p.amount)
+	done := make(chan struct{})
+
+	// receive echoes
+	go func() {
+		defer close(done)
+		buf := make([]byte, udpPacketSize)
+		for received := 0; received < p.amount; {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			now := time.Now()
+			if n != udpPacketSize || string(buf[:8]) != string(id) {
+				continue
+			}
+			seq := binary.BigEndian.Uint64(buf[8:])
+			mu.Lock()
+			if seq < uint64(p.amount) && !sent[seq].IsZero() && rtts[seq] == 0 {
+				rtts[seq] = now.Sub(sent[seq])
+				received++
+			}
+			mu.Unlock()
+		}
+	}()
+
+	// send burst
+	packet := make([]byte, udpPacketSize)
+	copy(packet, id)
+	for seq := 0; seq < p.amount; seq++ {
+		if seq > 0 {
+			select {
+			case <-time.After(p.spacing):
+			case <-ctx.Done():
+			}
+		}
+		binary.BigEndian.PutUint64(packet[8:], uint64(seq))
+		mu.Lock()
+		sent[seq] = time.Now()
+		mu.Unlock()
+		conn.Write(packet)
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// unblock the receiver
+		conn.SetReadDeadline(time.Now())
+		<-done
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var answered []time.Duration
+	for _, rtt := range rtts {
+		if rtt > 0 {
+			answered = append(answered, rtt)
+		}
+	}
+	return BurstSamples(keys, answered, p.amount)
+}
@@ -0,0 +1,129 @@
+/*
+ * canary-bot
+ *
+ * (C) 2022, Maximilian Schubert, Deutsche Telekom IT GmbH
+ *
+ * Deutsche Telekom IT GmbH and all other contributors /
+ * copyright owners license this file to you under the Apache
+ * License, Version 2.0 (the "License"); you may not use this
+ * file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/telekom/canary-bot/data"
+	"go.uber.org/zap"
+)
+
+func Test_UDPProbeRun(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	defer conn.Close()
+	go ServeUDPEcho(conn, zap.NewNop().Sugar())
+
+	port := int64(conn.LocalAddr().(*net.UDPAddr).Port)
+	p := NewUDPProbe(port, 5, time.Millisecond, time.Second, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+	defer cancel()
+	// the port of the target address will be replaced by the echo port
+	samples := p.Run(ctx, Target{Name: "owl", Address: "127.0.0.1:8081"})
+
+	if len(samples) != 5 {
+		t.Fatalf("the amount of samples (%v) is not as expected: 5", len(samples))
+	}
+	for _, sample := range samples {
+		if sample.Failed {
+			t.Errorf("sample %v failed", data.SampleName[sample.Key])
+		}
+		if sample.Key == data.UdpLossPercent && sample.Value != 0 {
+			t.Errorf("loss %v is not as expected: 0", sample.Value)
+		}
+	}
+}
+
+func Test_UDPProbeRunNoEcho(t *testing.T) {
+	// reserve a port without an echo listener
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	port := int64(conn.LocalAddr().(*net.UDPAddr).Port)
+	conn.Close()
+
+	p := NewUDPProbe(port, 3, time.Millisecond, time.Second, 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+	defer cancel()
+	samples := p.Run(ctx, Target{Name: "owl", Address: "127.0.0.1:8081"})
+
+	if samples[0].Key != data.UdpLossPercent || samples[0].Value != 100 {
+		t.Errorf("loss sample %+v is not as expected: 100", samples[0])
+	}
+}
+
+// failingConn fails the first reply
+type failingConn struct {
+	net.PacketConn
+	failed bool
+}
+
+func (c *failingConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	if !c.failed {
+		c.failed = true
+		return 0, errors.New("no route to host")
+	}
+	return c.PacketConn.WriteTo(b, addr)
+}
+
+func Test_ServeUDPEchoWriteError(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- ServeUDPEcho(&failingConn{PacketConn: conn}, zap.NewNop().Sugar()) }()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer client.Close()
+
+	// the first reply fails, the echo keeps serving the next packet
+	buf := make([]byte, udpPacketSize)
+	client.Write([]byte("first"))
+	client.Write([]byte("second"))
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := client.Read(buf)
+	if err != nil {
+		t.Fatalf("no echo after a failed reply: %v", err)
+	}
+	if string(buf[:n]) != "second" {
+		t.Errorf("echo (%s) is not as expected: second", buf[:n])
+	}
+
+	conn.Close()
+	if err := <-done; err != nil {
+		t.Errorf("echo stopped with error: %v", err)
+	}
+}
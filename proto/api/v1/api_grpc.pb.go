@@ -23,6 +23,8 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ApiServiceClient interface {
 	ListSamples(ctx context.Context, in *ListSampleRequest, opts ...grpc.CallOption) (*ListSampleResponse, error)
+	ListSampleHistory(ctx context.Context, in *ListSampleHistoryRequest, opts ...grpc.CallOption) (*ListSampleHistoryResponse, error)
+	GetSampleStats(ctx context.Context, in *GetSampleStatsRequest, opts ...grpc.CallOption) (*GetSampleStatsResponse, error)
 	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error)
 }
 
@@ -43,6 +45,24 @@ func (c *apiServiceClient) ListSamples(ctx context.Context, in *ListSampleReques
 	return out, nil
 }
 
+func (c *apiServiceClient) ListSampleHistory(ctx context.Context, in *ListSampleHistoryRequest, opts ...grpc.CallOption) (*ListSampleHistoryResponse, error) {
+	out := new(ListSampleHistoryResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ListSampleHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetSampleStats(ctx context.Context, in *GetSampleStatsRequest, opts ...grpc.CallOption) (*GetSampleStatsResponse, error) {
+	out := new(GetSampleStatsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/GetSampleStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error) {
 	out := new(ListNodesResponse)
 	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ListNodes", in, out, opts...)
@@ -57,6 +77,8 @@ func (c *apiServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest,
 // for forward compatibility
 type ApiServiceServer interface {
 	ListSamples(context.Context, *ListSampleRequest) (*ListSampleResponse, error)
+	ListSampleHistory(context.Context, *ListSampleHistoryRequest) (*ListSampleHistoryResponse, error)
+	GetSampleStats(context.Context, *GetSampleStatsRequest) (*GetSampleStatsResponse, error)
 	ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error)
 	mustEmbedUnimplementedApiServiceServer()
 }
@@ -68,6 +90,12 @@ type UnimplementedApiServiceServer struct {
 func (UnimplementedApiServiceServer) ListSamples(context.Context, *ListSampleRequest) (*ListSampleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSamples not implemented")
 }
+func (UnimplementedApiServiceServer) ListSampleHistory(context.Context, *ListSampleHistoryRequest) (*ListSampleHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSampleHistory not implemented")
+}
+func (UnimplementedApiServiceServer) GetSampleStats(context.Context, *GetSampleStatsRequest) (*GetSampleStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSampleStats not implemented")
+}
 func (UnimplementedApiServiceServer) ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListNodes not implemented")
 }
@@ -102,6 +130,42 @@ func _ApiService_ListSamples_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_ListSampleHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSampleHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ListSampleHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ListSampleHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ListSampleHistory(ctx, req.(*ListSampleHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetSampleStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSampleStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetSampleStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/GetSampleStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetSampleStats(ctx, req.(*GetSampleStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListNodesRequest)
 	if err := dec(in); err != nil {
@@ -131,6 +195,14 @@ var ApiService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSamples",
 			Handler:    _ApiService_ListSamples_Handler,
 		},
+		{
+			MethodName: "ListSampleHistory",
+			Handler:    _ApiService_ListSampleHistory_Handler,
+		},
+		{
+			MethodName: "GetSampleStats",
+			Handler:    _ApiService_GetSampleStats_Handler,
+		},
 		{
 			MethodName: "ListNodes",
 			Handler:    _ApiService_ListNodes_Handler,
@@ -25,9 +25,31 @@ const (
 	ApiServiceName = "api.v1.ApiService"
 )
 
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ApiServiceListSamplesProcedure is the fully-qualified name of the ApiService's ListSamples RPC.
+	ApiServiceListSamplesProcedure = "/api.v1.ApiService/ListSamples"
+	// ApiServiceListSampleHistoryProcedure is the fully-qualified name of the ApiService's
+	// ListSampleHistory RPC.
+	ApiServiceListSampleHistoryProcedure = "/api.v1.ApiService/ListSampleHistory"
+	// ApiServiceGetSampleStatsProcedure is the fully-qualified name of the ApiService's GetSampleStats
+	// RPC.
+	ApiServiceGetSampleStatsProcedure = "/api.v1.ApiService/GetSampleStats"
+	// ApiServiceListNodesProcedure is the fully-qualified name of the ApiService's ListNodes RPC.
+	ApiServiceListNodesProcedure = "/api.v1.ApiService/ListNodes"
+)
+
 // ApiServiceClient is a client for the api.v1.ApiService service.
 type ApiServiceClient interface {
 	ListSamples(context.Context, *connect_go.Request[v1.ListSampleRequest]) (*connect_go.Response[v1.ListSampleResponse], error)
+	ListSampleHistory(context.Context, *connect_go.Request[v1.ListSampleHistoryRequest]) (*connect_go.Response[v1.ListSampleHistoryResponse], error)
+	GetSampleStats(context.Context, *connect_go.Request[v1.GetSampleStatsRequest]) (*connect_go.Response[v1.GetSampleStatsResponse], error)
 	ListNodes(context.Context, *connect_go.Request[v1.ListNodesRequest]) (*connect_go.Response[v1.ListNodesResponse], error)
 }
 
@@ -43,12 +65,22 @@ func NewApiServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts
 	return &apiServiceClient{
 		listSamples: connect_go.NewClient[v1.ListSampleRequest, v1.ListSampleResponse](
 			httpClient,
-			baseURL+"/api.v1.ApiService/ListSamples",
+			baseURL+ApiServiceListSamplesProcedure,
+			opts...,
+		),
+		listSampleHistory: connect_go.NewClient[v1.ListSampleHistoryRequest, v1.ListSampleHistoryResponse](
+			httpClient,
+			baseURL+ApiServiceListSampleHistoryProcedure,
+			opts...,
+		),
+		getSampleStats: connect_go.NewClient[v1.GetSampleStatsRequest, v1.GetSampleStatsResponse](
+			httpClient,
+			baseURL+ApiServiceGetSampleStatsProcedure,
 			opts...,
 		),
 		listNodes: connect_go.NewClient[v1.ListNodesRequest, v1.ListNodesResponse](
 			httpClient,
-			baseURL+"/api.v1.ApiService/ListNodes",
+			baseURL+ApiServiceListNodesProcedure,
 			opts...,
 		),
 	}
@@ -56,8 +88,10 @@ func NewApiServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts
 
 // apiServiceClient implements ApiServiceClient.
 type apiServiceClient struct {
-	listSamples *connect_go.Client[v1.ListSampleRequest, v1.ListSampleResponse]
-	listNodes   *connect_go.Client[v1.ListNodesRequest, v1.ListNodesResponse]
+	listSamples       *connect_go.Client[v1.ListSampleRequest, v1.ListSampleResponse]
+	listSampleHistory *connect_go.Client[v1.ListSampleHistoryRequest, v1.ListSampleHistoryResponse]
+	getSampleStats    *connect_go.Client[v1.GetSampleStatsRequest, v1.GetSampleStatsResponse]
+	listNodes         *connect_go.Client[v1.ListNodesRequest, v1.ListNodesResponse]
 }
 
 // ListSamples calls api.v1.ApiService.ListSamples.
@@ -65,6 +99,16 @@ func (c *apiServiceClient) ListSamples(ctx context.Context, req *connect_go.Requ
 	return c.listSamples.CallUnary(ctx, req)
 }
 
+// ListSampleHistory calls api.v1.ApiService.ListSampleHistory.
+func (c *apiServiceClient) ListSampleHistory(ctx context.Context, req *connect_go.Request[v1.ListSampleHistoryRequest]) (*connect_go.Response[v1.ListSampleHistoryResponse], error) {
+	return c.listSampleHistory.CallUnary(ctx, req)
+}
+
+// GetSampleStats calls api.v1.ApiService.GetSampleStats.
+func (c *apiServiceClient) GetSampleStats(ctx context.Context, req *connect_go.Request[v1.GetSampleStatsRequest]) (*connect_go.Response[v1.GetSampleStatsResponse], error) {
+	return c.getSampleStats.CallUnary(ctx, req)
+}
+
 // ListNodes calls api.v1.ApiService.ListNodes.
 func (c *apiServiceClient) ListNodes(ctx context.Context, req *connect_go.Request[v1.ListNodesRequest]) (*connect_go.Response[v1.ListNodesResponse], error) {
 	return c.listNodes.CallUnary(ctx, req)
@@ -73,6 +117,8 @@ func (c *apiServiceClient) ListNodes(ctx context.Context, req *connect_go.Reques
 // ApiServiceHandler is an implementation of the api.v1.ApiService service.
 type ApiServiceHandler interface {
 	ListSamples(context.Context, *connect_go.Request[v1.ListSampleRequest]) (*connect_go.Response[v1.ListSampleResponse], error)
+	ListSampleHistory(context.Context, *connect_go.Request[v1.ListSampleHistoryRequest]) (*connect_go.Response[v1.ListSampleHistoryResponse], error)
+	GetSampleStats(context.Context, *connect_go.Request[v1.GetSampleStatsRequest]) (*connect_go.Response[v1.GetSampleStatsResponse], error)
 	ListNodes(context.Context, *connect_go.Request[v1.ListNodesRequest]) (*connect_go.Response[v1.ListNodesResponse], error)
 }
 
@@ -82,18 +128,40 @@ type ApiServiceHandler interface {
 // By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
 // and JSON codecs. They also support gzip compression.
 func NewApiServiceHandler(svc ApiServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
-	mux := http.NewServeMux()
-	mux.Handle("/api.v1.ApiService/ListSamples", connect_go.NewUnaryHandler(
-		"/api.v1.ApiService/ListSamples",
+	apiServiceListSamplesHandler := connect_go.NewUnaryHandler(
+		ApiServiceListSamplesProcedure,
 		svc.ListSamples,
 		opts...,
-	))
-	mux.Handle("/api.v1.ApiService/ListNodes", connect_go.NewUnaryHandler(
-		"/api.v1.ApiService/ListNodes",
+	)
+	apiServiceListSampleHistoryHandler := connect_go.NewUnaryHandler(
+		ApiServiceListSampleHistoryProcedure,
+		svc.ListSampleHistory,
+		opts...,
+	)
+	apiServiceGetSampleStatsHandler := connect_go.NewUnaryHandler(
+		ApiServiceGetSampleStatsProcedure,
+		svc.GetSampleStats,
+		opts...,
+	)
+	apiServiceListNodesHandler := connect_go.NewUnaryHandler(
+		ApiServiceListNodesProcedure,
 		svc.ListNodes,
 		opts...,
-	))
-	return "/api.v1.ApiService/", mux
+	)
+	return "/api.v1.ApiService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ApiServiceListSamplesProcedure:
+			apiServiceListSamplesHandler.ServeHTTP(w, r)
+		case ApiServiceListSampleHistoryProcedure:
+			apiServiceListSampleHistoryHandler.ServeHTTP(w, r)
+		case ApiServiceGetSampleStatsProcedure:
+			apiServiceGetSampleStatsHandler.ServeHTTP(w, r)
+		case ApiServiceListNodesProcedure:
+			apiServiceListNodesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
 }
 
 // UnimplementedApiServiceHandler returns CodeUnimplemented from all methods.
@@ -103,6 +171,14 @@ func (UnimplementedApiServiceHandler) ListSamples(context.Context, *connect_go.R
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.ApiService.ListSamples is not implemented"))
 }
 
+func (UnimplementedApiServiceHandler) ListSampleHistory(context.Context, *connect_go.Request[v1.ListSampleHistoryRequest]) (*connect_go.Response[v1.ListSampleHistoryResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.ApiService.ListSampleHistory is not implemented"))
+}
+
+func (UnimplementedApiServiceHandler) GetSampleStats(context.Context, *connect_go.Request[v1.GetSampleStatsRequest]) (*connect_go.Response[v1.GetSampleStatsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.ApiService.GetSampleStats is not implemented"))
+}
+
 func (UnimplementedApiServiceHandler) ListNodes(context.Context, *connect_go.Request[v1.ListNodesRequest]) (*connect_go.Response[v1.ListNodesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.ApiService.ListNodes is not implemented"))
 }
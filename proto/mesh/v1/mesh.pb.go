@@ -21,33 +21,692 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SampleStatus int32
+
+const (
+	SampleStatus_SAMPLE_STATUS_UNSPECIFIED SampleStatus = 0
+	SampleStatus_SAMPLE_STATUS_OK          SampleStatus = 1
+	SampleStatus_SAMPLE_STATUS_TIMEOUT     SampleStatus = 2
+	SampleStatus_SAMPLE_STATUS_ERROR       SampleStatus = 3
+)
+
+// Enum value maps for SampleStatus.
+var (
+	SampleStatus_name = map[int32]string{
+		0: "SAMPLE_STATUS_UNSPECIFIED",
+		1: "SAMPLE_STATUS_OK",
+		2: "SAMPLE_STATUS_TIMEOUT",
+		3: "SAMPLE_STATUS_ERROR",
+	}
+	SampleStatus_value = map[string]int32{
+		"SAMPLE_STATUS_UNSPECIFIED": 0,
+		"SAMPLE_STATUS_OK":          1,
+		"SAMPLE_STATUS_TIMEOUT":     2,
+		"SAMPLE_STATUS_ERROR":       3,
+	}
+)
+
+func (x SampleStatus) Enum() *SampleStatus {
+	p := new(SampleStatus)
+	*p = x
+	return p
+}
+
+func (x SampleStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SampleStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_mesh_proto_enumTypes[0].Descriptor()
+}
+
+func (SampleStatus) Type() protoreflect.EnumType {
+	return &file_v1_mesh_proto_enumTypes[0]
+}
+
+func (x SampleStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SampleStatus.Descriptor instead.
+func (SampleStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{0}
+}
+
 type JoinMeshResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NameUnique bool    `protobuf:"varint,1,opt,name=name_unique,json=nameUnique,proto3" json:"name_unique,omitempty"`
-	MyName     string  `protobuf:"bytes,2,opt,name=my_name,json=myName,proto3" json:"my_name,omitempty"`
-	Nodes      []*Node `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NameUnique bool    `protobuf:"varint,1,opt,name=name_unique,json=nameUnique,proto3" json:"name_unique,omitempty"`
+	MyName     string  `protobuf:"bytes,2,opt,name=my_name,json=myName,proto3" json:"my_name,omitempty"`
+	Nodes      []*Node `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *JoinMeshResponse) Reset() {
+	*x = JoinMeshResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinMeshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinMeshResponse) ProtoMessage() {}
+
+func (x *JoinMeshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinMeshResponse.ProtoReflect.Descriptor instead.
+func (*JoinMeshResponse) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *JoinMeshResponse) GetNameUnique() bool {
+	if x != nil {
+		return x.NameUnique
+	}
+	return false
+}
+
+func (x *JoinMeshResponse) GetMyName() string {
+	if x != nil {
+		return x.MyName
+	}
+	return ""
+}
+
+func (x *JoinMeshResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type NodeDiscoveryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NewNode *Node `protobuf:"bytes,1,opt,name=new_node,json=newNode,proto3" json:"new_node,omitempty"`
+	IAmNode *Node `protobuf:"bytes,2,opt,name=i_am_node,json=iAmNode,proto3" json:"i_am_node,omitempty"`
+}
+
+func (x *NodeDiscoveryRequest) Reset() {
+	*x = NodeDiscoveryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeDiscoveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeDiscoveryRequest) ProtoMessage() {}
+
+func (x *NodeDiscoveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeDiscoveryRequest.ProtoReflect.Descriptor instead.
+func (*NodeDiscoveryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NodeDiscoveryRequest) GetNewNode() *Node {
+	if x != nil {
+		return x.NewNode
+	}
+	return nil
+}
+
+func (x *NodeDiscoveryRequest) GetIAmNode() *Node {
+	if x != nil {
+		return x.IAmNode
+	}
+	return nil
+}
+
+type NodeStateUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node    *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	State   int64 `protobuf:"varint,2,opt,name=state,proto3" json:"state,omitempty"`
+	IAmNode *Node `protobuf:"bytes,3,opt,name=i_am_node,json=iAmNode,proto3" json:"i_am_node,omitempty"`
+}
+
+func (x *NodeStateUpdateRequest) Reset() {
+	*x = NodeStateUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeStateUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeStateUpdateRequest) ProtoMessage() {}
+
+func (x *NodeStateUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeStateUpdateRequest.ProtoReflect.Descriptor instead.
+func (*NodeStateUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NodeStateUpdateRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *NodeStateUpdateRequest) GetState() int64 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *NodeStateUpdateRequest) GetIAmNode() *Node {
+	if x != nil {
+		return x.IAmNode
+	}
+	return nil
+}
+
+type PingReqRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target  *Node `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	IAmNode *Node `protobuf:"bytes,2,opt,name=i_am_node,json=iAmNode,proto3" json:"i_am_node,omitempty"`
+}
+
+func (x *PingReqRequest) Reset() {
+	*x = PingReqRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PingReqRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingReqRequest) ProtoMessage() {}
+
+func (x *PingReqRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingReqRequest.ProtoReflect.Descriptor instead.
+func (*PingReqRequest) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PingReqRequest) GetTarget() *Node {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *PingReqRequest) GetIAmNode() *Node {
+	if x != nil {
+		return x.IAmNode
+	}
+	return nil
+}
+
+type PingReqResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok bool `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+}
+
+func (x *PingReqResponse) Reset() {
+	*x = PingReqResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PingReqResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingReqResponse) ProtoMessage() {}
+
+func (x *PingReqResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingReqResponse.ProtoReflect.Descriptor instead.
+func (*PingReqResponse) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PingReqResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Target      string            `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Incarnation uint64            `protobuf:"varint,3,opt,name=incarnation,proto3" json:"incarnation,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Node) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Node) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Node) GetIncarnation() uint64 {
+	if x != nil {
+		return x.Incarnation
+	}
+	return 0
+}
+
+func (x *Node) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type Samples struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Samples []*Sample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+	// highest local sequence number of the pushed samples
+	Seq uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *Samples) Reset() {
+	*x = Samples{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Samples) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Samples) ProtoMessage() {}
+
+func (x *Samples) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Samples.ProtoReflect.Descriptor instead.
+func (*Samples) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Samples) GetSamples() []*Sample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *Samples) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type PushSamplesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// acknowledged sequence number of the pushed samples
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// amount of samples newer than the ones of the receiver
+	Accepted int64 `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+}
+
+func (x *PushSamplesResponse) Reset() {
+	*x = PushSamplesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushSamplesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushSamplesResponse) ProtoMessage() {}
+
+func (x *PushSamplesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushSamplesResponse.ProtoReflect.Descriptor instead.
+func (*PushSamplesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PushSamplesResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PushSamplesResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+type StateDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sample ID -> timestamp of the sample
+	Samples map[uint32]int64 `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// node name -> incarnation of the node
+	Nodes   map[string]uint64 `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	IAmNode *Node             `protobuf:"bytes,3,opt,name=i_am_node,json=iAmNode,proto3" json:"i_am_node,omitempty"`
+}
+
+func (x *StateDigest) Reset() {
+	*x = StateDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateDigest) ProtoMessage() {}
+
+func (x *StateDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateDigest.ProtoReflect.Descriptor instead.
+func (*StateDigest) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StateDigest) GetSamples() map[uint32]int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *StateDigest) GetNodes() map[string]uint64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *StateDigest) GetIAmNode() *Node {
+	if x != nil {
+		return x.IAmNode
+	}
+	return nil
+}
+
+type PullStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// samples missing or newer than in the digest
+	Samples []*Sample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+	// nodes missing or with a newer incarnation than in the digest
+	Nodes []*Node `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// removed nodes within the tombstone TTL, peers must not re-add them
+	Tombstones []*Tombstone `protobuf:"bytes,3,rep,name=tombstones,proto3" json:"tombstones,omitempty"`
+	// the responding node
+	IAmNode *Node `protobuf:"bytes,4,opt,name=i_am_node,json=iAmNode,proto3" json:"i_am_node,omitempty"`
+}
+
+func (x *PullStateResponse) Reset() {
+	*x = PullStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PullStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullStateResponse) ProtoMessage() {}
+
+func (x *PullStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullStateResponse.ProtoReflect.Descriptor instead.
+func (*PullStateResponse) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PullStateResponse) GetSamples() []*Sample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *PullStateResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *PullStateResponse) GetTombstones() []*Tombstone {
+	if x != nil {
+		return x.Tombstones
+	}
+	return nil
+}
+
+func (x *PullStateResponse) GetIAmNode() *Node {
+	if x != nil {
+		return x.IAmNode
+	}
+	return nil
+}
+
+type Tombstone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// state of the removed node, dead or left
+	State int64 `protobuf:"varint,2,opt,name=state,proto3" json:"state,omitempty"`
 }
 
-func (x *JoinMeshResponse) Reset() {
-	*x = JoinMeshResponse{}
+func (x *Tombstone) Reset() {
+	*x = Tombstone{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_mesh_proto_msgTypes[0]
+		mi := &file_v1_mesh_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *JoinMeshResponse) String() string {
+func (x *Tombstone) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinMeshResponse) ProtoMessage() {}
+func (*Tombstone) ProtoMessage() {}
 
-func (x *JoinMeshResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_mesh_proto_msgTypes[0]
+func (x *Tombstone) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,58 +717,51 @@ func (x *JoinMeshResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinMeshResponse.ProtoReflect.Descriptor instead.
-func (*JoinMeshResponse) Descriptor() ([]byte, []int) {
-	return file_v1_mesh_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *JoinMeshResponse) GetNameUnique() bool {
-	if x != nil {
-		return x.NameUnique
-	}
-	return false
+// Deprecated: Use Tombstone.ProtoReflect.Descriptor instead.
+func (*Tombstone) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *JoinMeshResponse) GetMyName() string {
+func (x *Tombstone) GetNode() *Node {
 	if x != nil {
-		return x.MyName
+		return x.Node
 	}
-	return ""
+	return nil
 }
 
-func (x *JoinMeshResponse) GetNodes() []*Node {
+func (x *Tombstone) GetState() int64 {
 	if x != nil {
-		return x.Nodes
+		return x.State
 	}
-	return nil
+	return 0
 }
 
-type NodeDiscoveryRequest struct {
+type RttResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NewNode *Node `protobuf:"bytes,1,opt,name=new_node,json=newNode,proto3" json:"new_node,omitempty"`
-	IAmNode *Node `protobuf:"bytes,2,opt,name=i_am_node,json=iAmNode,proto3" json:"i_am_node,omitempty"`
+	// time of the receiver in unix nanoseconds to estimate the clock offset
+	Ts int64 `protobuf:"varint,1,opt,name=ts,proto3" json:"ts,omitempty"`
 }
 
-func (x *NodeDiscoveryRequest) Reset() {
-	*x = NodeDiscoveryRequest{}
+func (x *RttResponse) Reset() {
+	*x = RttResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_mesh_proto_msgTypes[1]
+		mi := &file_v1_mesh_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NodeDiscoveryRequest) String() string {
+func (x *RttResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeDiscoveryRequest) ProtoMessage() {}
+func (*RttResponse) ProtoMessage() {}
 
-func (x *NodeDiscoveryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_mesh_proto_msgTypes[1]
+func (x *RttResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -120,51 +772,93 @@ func (x *NodeDiscoveryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodeDiscoveryRequest.ProtoReflect.Descriptor instead.
-func (*NodeDiscoveryRequest) Descriptor() ([]byte, []int) {
-	return file_v1_mesh_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RttResponse.ProtoReflect.Descriptor instead.
+func (*RttResponse) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *NodeDiscoveryRequest) GetNewNode() *Node {
+func (x *RttResponse) GetTs() int64 {
 	if x != nil {
-		return x.NewNode
+		return x.Ts
 	}
-	return nil
+	return 0
 }
 
-func (x *NodeDiscoveryRequest) GetIAmNode() *Node {
+type ThroughputChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *ThroughputChunk) Reset() {
+	*x = ThroughputChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_mesh_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThroughputChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThroughputChunk) ProtoMessage() {}
+
+func (x *ThroughputChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThroughputChunk.ProtoReflect.Descriptor instead.
+func (*ThroughputChunk) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ThroughputChunk) GetPayload() []byte {
 	if x != nil {
-		return x.IAmNode
+		return x.Payload
 	}
 	return nil
 }
 
-type Node struct {
+type ThroughputRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// size of a chunk in bytes
+	ChunkSize int64 `protobuf:"varint,1,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// duration of the transfer in nanoseconds
+	Duration int64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+func (x *ThroughputRequest) Reset() {
+	*x = ThroughputRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_mesh_proto_msgTypes[2]
+		mi := &file_v1_mesh_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Node) String() string {
+func (x *ThroughputRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*ThroughputRequest) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_mesh_proto_msgTypes[2]
+func (x *ThroughputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,50 +869,53 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
-	return file_v1_mesh_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ThroughputRequest.ProtoReflect.Descriptor instead.
+func (*ThroughputRequest) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Node) GetName() string {
+func (x *ThroughputRequest) GetChunkSize() int64 {
 	if x != nil {
-		return x.Name
+		return x.ChunkSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Node) GetTarget() string {
+func (x *ThroughputRequest) GetDuration() int64 {
 	if x != nil {
-		return x.Target
+		return x.Duration
 	}
-	return ""
+	return 0
 }
 
-type Samples struct {
+type ThroughputResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Samples []*Sample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+	// received bytes
+	Bytes int64 `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// duration of the transfer measured by the receiver in nanoseconds
+	Duration int64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
 }
 
-func (x *Samples) Reset() {
-	*x = Samples{}
+func (x *ThroughputResponse) Reset() {
+	*x = ThroughputResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_mesh_proto_msgTypes[3]
+		mi := &file_v1_mesh_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Samples) String() string {
+func (x *ThroughputResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Samples) ProtoMessage() {}
+func (*ThroughputResponse) ProtoMessage() {}
 
-func (x *Samples) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_mesh_proto_msgTypes[3]
+func (x *ThroughputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_mesh_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,16 +926,23 @@ func (x *Samples) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Samples.ProtoReflect.Descriptor instead.
-func (*Samples) Descriptor() ([]byte, []int) {
-	return file_v1_mesh_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ThroughputResponse.ProtoReflect.Descriptor instead.
+func (*ThroughputResponse) Descriptor() ([]byte, []int) {
+	return file_v1_mesh_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Samples) GetSamples() []*Sample {
+func (x *ThroughputResponse) GetBytes() int64 {
 	if x != nil {
-		return x.Samples
+		return x.Bytes
 	}
-	return nil
+	return 0
+}
+
+func (x *ThroughputResponse) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
 }
 
 type Sample struct {
@@ -246,17 +950,21 @@ type Sample struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	From  string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To    string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	Key   int64  `protobuf:"varint,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	Ts    int64  `protobuf:"varint,5,opt,name=ts,proto3" json:"ts,omitempty"`
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Key  int64  `protobuf:"varint,3,opt,name=key,proto3" json:"key,omitempty"`
+	// value as string for nodes without numeric values
+	Value        string       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Ts           int64        `protobuf:"varint,5,opt,name=ts,proto3" json:"ts,omitempty"`
+	NumericValue float64      `protobuf:"fixed64,6,opt,name=numeric_value,json=numericValue,proto3" json:"numeric_value,omitempty"`
+	Status       SampleStatus `protobuf:"varint,7,opt,name=status,proto3,enum=mesh.v1.SampleStatus" json:"status,omitempty"`
+	Unit         string       `protobuf:"bytes,8,opt,name=unit,proto3" json:"unit,omitempty"`
 }
 
 func (x *Sample) Reset() {
 	*x = Sample{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_mesh_proto_msgTypes[4]
+		mi := &file_v1_mesh_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -269,7 +977,7 @@ func (x *Sample) String() string {
 func (*Sample) ProtoMessage() {}
 
 func (x *Sample) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_mesh_proto_msgTypes[4]
+	mi := &file_v1_mesh_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +990,7 @@ func (x *Sample) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sample.ProtoReflect.Descriptor instead.
 func (*Sample) Descriptor() ([]byte, []int) {
-	return file_v1_mesh_proto_rawDescGZIP(), []int{4}
+	return file_v1_mesh_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Sample) GetFrom() string {
@@ -320,6 +1028,27 @@ func (x *Sample) GetTs() int64 {
 	return 0
 }
 
+func (x *Sample) GetNumericValue() float64 {
+	if x != nil {
+		return x.NumericValue
+	}
+	return 0
+}
+
+func (x *Sample) GetStatus() SampleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SampleStatus_SAMPLE_STATUS_UNSPECIFIED
+}
+
+func (x *Sample) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
 var File_v1_mesh_proto protoreflect.FileDescriptor
 
 var file_v1_mesh_proto_rawDesc = []byte{
@@ -340,43 +1069,164 @@ var file_v1_mesh_proto_rawDesc = []byte{
 	0x65, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x69, 0x5f,
 	0x61, 0x6d, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
 	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x69, 0x41,
-	0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x32, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x34, 0x0a, 0x07, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22,
-	0x64, 0x0a, 0x06, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
-	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
-	0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x02, 0x74, 0x73, 0x32, 0xb4, 0x02, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x68, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x4d, 0x65, 0x73,
-	0x68, 0x12, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
-	0x1a, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x4d,
-	0x65, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a,
-	0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e,
-	0x4e, 0x6f, 0x64, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48,
-	0x0a, 0x0d, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12,
-	0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x69,
-	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x03, 0x52, 0x74, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x38, 0x5a, 0x36,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x6c, 0x65, 0x6b,
-	0x6f, 0x6d, 0x2f, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x2d, 0x62, 0x6f, 0x74, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x6d, 0x65, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x3b,
-	0x6d, 0x65, 0x73, 0x68, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x7c, 0x0a, 0x16, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x69, 0x5f, 0x61, 0x6d,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x69, 0x41, 0x6d, 0x4e,
+	0x6f, 0x64, 0x65, 0x22, 0x62, 0x0a, 0x0e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x29, 0x0a, 0x09,
+	0x69, 0x5f, 0x61, 0x6d, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x07,
+	0x69, 0x41, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x21, 0x0a, 0x0f, 0x50, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0xc2, 0x01, 0x0a, 0x04, 0x4e,
+	0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x61, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x61, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x31, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x46, 0x0a, 0x07, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x43, 0x0a, 0x13, 0x50, 0x75, 0x73, 0x68, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x22, 0xa2, 0x02, 0x0a,
+	0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x07,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x29, 0x0a, 0x09, 0x69, 0x5f, 0x61, 0x6d, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x07, 0x69, 0x41, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xc2, 0x01, 0x0a, 0x11, 0x50, 0x75, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x74, 0x6f, 0x6d, 0x62, 0x73,
+	0x74, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x52,
+	0x0a, 0x74, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x09, 0x69,
+	0x5f, 0x61, 0x6d, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x69,
+	0x41, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x44, 0x0a, 0x09, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74,
+	0x6f, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x1d, 0x0a, 0x0b,
+	0x52, 0x74, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x22, 0x2b, 0x0a, 0x0f, 0x54,
+	0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x4e, 0x0a, 0x11, 0x54, 0x68, 0x72, 0x6f,
+	0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x46, 0x0a, 0x12, 0x54, 0x68, 0x72, 0x6f,
+	0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0xcc, 0x01, 0x0a, 0x06, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72,
+	0x69, 0x63, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2d, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x6e, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x2a,
+	0x77, 0x0a, 0x0c, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x1d, 0x0a, 0x19, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x4f, 0x4b, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x12,
+	0x17, 0x0a, 0x13, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x32, 0xdc, 0x05, 0x0a, 0x0b, 0x4d, 0x65, 0x73,
+	0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e,
+	0x4d, 0x65, 0x73, 0x68, 0x12, 0x0d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x1a, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
+	0x69, 0x6e, 0x4d, 0x65, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x34, 0x0a, 0x09, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x4d, 0x65, 0x73, 0x68, 0x12, 0x0d, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x0d,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x07, 0x50, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x12, 0x17, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x4e, 0x6f, 0x64, 0x65, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1d, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x4c, 0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x3f, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x10,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x3f, 0x0a, 0x09, 0x50, 0x75, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75,
+	0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x35, 0x0a, 0x03, 0x52, 0x74, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x74, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x10, 0x54, 0x68, 0x72, 0x6f,
+	0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x18, 0x2e, 0x6d,
+	0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75,
+	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4e, 0x0a, 0x12, 0x54, 0x68, 0x72, 0x6f, 0x75,
+	0x67, 0x68, 0x70, 0x75, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x6c, 0x65, 0x6b, 0x6f, 0x6d, 0x2f, 0x63, 0x61,
+	0x6e, 0x61, 0x72, 0x79, 0x2d, 0x62, 0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x6d, 0x65, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x6d, 0x65, 0x73, 0x68, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -391,35 +1241,77 @@ func file_v1_mesh_proto_rawDescGZIP() []byte {
 	return file_v1_mesh_proto_rawDescData
 }
 
-var file_v1_mesh_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_v1_mesh_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_mesh_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_v1_mesh_proto_goTypes = []interface{}{
-	(*JoinMeshResponse)(nil),     // 0: mesh.v1.JoinMeshResponse
-	(*NodeDiscoveryRequest)(nil), // 1: mesh.v1.NodeDiscoveryRequest
-	(*Node)(nil),                 // 2: mesh.v1.Node
-	(*Samples)(nil),              // 3: mesh.v1.Samples
-	(*Sample)(nil),               // 4: mesh.v1.Sample
-	(*emptypb.Empty)(nil),        // 5: google.protobuf.Empty
+	(SampleStatus)(0),              // 0: mesh.v1.SampleStatus
+	(*JoinMeshResponse)(nil),       // 1: mesh.v1.JoinMeshResponse
+	(*NodeDiscoveryRequest)(nil),   // 2: mesh.v1.NodeDiscoveryRequest
+	(*NodeStateUpdateRequest)(nil), // 3: mesh.v1.NodeStateUpdateRequest
+	(*PingReqRequest)(nil),         // 4: mesh.v1.PingReqRequest
+	(*PingReqResponse)(nil),        // 5: mesh.v1.PingReqResponse
+	(*Node)(nil),                   // 6: mesh.v1.Node
+	(*Samples)(nil),                // 7: mesh.v1.Samples
+	(*PushSamplesResponse)(nil),    // 8: mesh.v1.PushSamplesResponse
+	(*StateDigest)(nil),            // 9: mesh.v1.StateDigest
+	(*PullStateResponse)(nil),      // 10: mesh.v1.PullStateResponse
+	(*Tombstone)(nil),              // 11: mesh.v1.Tombstone
+	(*RttResponse)(nil),            // 12: mesh.v1.RttResponse
+	(*ThroughputChunk)(nil),        // 13: mesh.v1.ThroughputChunk
+	(*ThroughputRequest)(nil),      // 14: mesh.v1.ThroughputRequest
+	(*ThroughputResponse)(nil),     // 15: mesh.v1.ThroughputResponse
+	(*Sample)(nil),                 // 16: mesh.v1.Sample
+	nil,                            // 17: mesh.v1.Node.LabelsEntry
+	nil,                            // 18: mesh.v1.StateDigest.SamplesEntry
+	nil,                            // 19: mesh.v1.StateDigest.NodesEntry
+	(*emptypb.Empty)(nil),          // 20: google.protobuf.Empty
 }
 var file_v1_mesh_proto_depIdxs = []int32{
-	2, // 0: mesh.v1.JoinMeshResponse.nodes:type_name -> mesh.v1.Node
-	2, // 1: mesh.v1.NodeDiscoveryRequest.new_node:type_name -> mesh.v1.Node
-	2, // 2: mesh.v1.NodeDiscoveryRequest.i_am_node:type_name -> mesh.v1.Node
-	4, // 3: mesh.v1.Samples.samples:type_name -> mesh.v1.Sample
-	2, // 4: mesh.v1.MeshService.JoinMesh:input_type -> mesh.v1.Node
-	2, // 5: mesh.v1.MeshService.Ping:input_type -> mesh.v1.Node
-	1, // 6: mesh.v1.MeshService.NodeDiscovery:input_type -> mesh.v1.NodeDiscoveryRequest
-	3, // 7: mesh.v1.MeshService.PushSamples:input_type -> mesh.v1.Samples
-	5, // 8: mesh.v1.MeshService.Rtt:input_type -> google.protobuf.Empty
-	0, // 9: mesh.v1.MeshService.JoinMesh:output_type -> mesh.v1.JoinMeshResponse
-	5, // 10: mesh.v1.MeshService.Ping:output_type -> google.protobuf.Empty
-	5, // 11: mesh.v1.MeshService.NodeDiscovery:output_type -> google.protobuf.Empty
-	5, // 12: mesh.v1.MeshService.PushSamples:output_type -> google.protobuf.Empty
-	5, // 13: mesh.v1.MeshService.Rtt:output_type -> google.protobuf.Empty
-	9, // [9:14] is the sub-list for method output_type
-	4, // [4:9] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6,  // 0: mesh.v1.JoinMeshResponse.nodes:type_name -> mesh.v1.Node
+	6,  // 1: mesh.v1.NodeDiscoveryRequest.new_node:type_name -> mesh.v1.Node
+	6,  // 2: mesh.v1.NodeDiscoveryRequest.i_am_node:type_name -> mesh.v1.Node
+	6,  // 3: mesh.v1.NodeStateUpdateRequest.node:type_name -> mesh.v1.Node
+	6,  // 4: mesh.v1.NodeStateUpdateRequest.i_am_node:type_name -> mesh.v1.Node
+	6,  // 5: mesh.v1.PingReqRequest.target:type_name -> mesh.v1.Node
+	6,  // 6: mesh.v1.PingReqRequest.i_am_node:type_name -> mesh.v1.Node
+	17, // 7: mesh.v1.Node.labels:type_name -> mesh.v1.Node.LabelsEntry
+	16, // 8: mesh.v1.Samples.samples:type_name -> mesh.v1.Sample
+	18, // 9: mesh.v1.StateDigest.samples:type_name -> mesh.v1.StateDigest.SamplesEntry
+	19, // 10: mesh.v1.StateDigest.nodes:type_name -> mesh.v1.StateDigest.NodesEntry
+	6,  // 11: mesh.v1.StateDigest.i_am_node:type_name -> mesh.v1.Node
+	16, // 12: mesh.v1.PullStateResponse.samples:type_name -> mesh.v1.Sample
+	6,  // 13: mesh.v1.PullStateResponse.nodes:type_name -> mesh.v1.Node
+	11, // 14: mesh.v1.PullStateResponse.tombstones:type_name -> mesh.v1.Tombstone
+	6,  // 15: mesh.v1.PullStateResponse.i_am_node:type_name -> mesh.v1.Node
+	6,  // 16: mesh.v1.Tombstone.node:type_name -> mesh.v1.Node
+	0,  // 17: mesh.v1.Sample.status:type_name -> mesh.v1.SampleStatus
+	6,  // 18: mesh.v1.MeshService.JoinMesh:input_type -> mesh.v1.Node
+	6,  // 19: mesh.v1.MeshService.LeaveMesh:input_type -> mesh.v1.Node
+	6,  // 20: mesh.v1.MeshService.Ping:input_type -> mesh.v1.Node
+	4,  // 21: mesh.v1.MeshService.PingReq:input_type -> mesh.v1.PingReqRequest
+	2,  // 22: mesh.v1.MeshService.NodeDiscovery:input_type -> mesh.v1.NodeDiscoveryRequest
+	3,  // 23: mesh.v1.MeshService.NodeStateUpdate:input_type -> mesh.v1.NodeStateUpdateRequest
+	7,  // 24: mesh.v1.MeshService.PushSamples:input_type -> mesh.v1.Samples
+	9,  // 25: mesh.v1.MeshService.PullState:input_type -> mesh.v1.StateDigest
+	20, // 26: mesh.v1.MeshService.Rtt:input_type -> google.protobuf.Empty
+	13, // 27: mesh.v1.MeshService.ThroughputUpload:input_type -> mesh.v1.ThroughputChunk
+	14, // 28: mesh.v1.MeshService.ThroughputDownload:input_type -> mesh.v1.ThroughputRequest
+	1,  // 29: mesh.v1.MeshService.JoinMesh:output_type -> mesh.v1.JoinMeshResponse
+	20, // 30: mesh.v1.MeshService.LeaveMesh:output_type -> google.protobuf.Empty
+	20, // 31: mesh.v1.MeshService.Ping:output_type -> google.protobuf.Empty
+	5,  // 32: mesh.v1.MeshService.PingReq:output_type -> mesh.v1.PingReqResponse
+	20, // 33: mesh.v1.MeshService.NodeDiscovery:output_type -> google.protobuf.Empty
+	20, // 34: mesh.v1.MeshService.NodeStateUpdate:output_type -> google.protobuf.Empty
+	8,  // 35: mesh.v1.MeshService.PushSamples:output_type -> mesh.v1.PushSamplesResponse
+	10, // 36: mesh.v1.MeshService.PullState:output_type -> mesh.v1.PullStateResponse
+	12, // 37: mesh.v1.MeshService.Rtt:output_type -> mesh.v1.RttResponse
+	15, // 38: mesh.v1.MeshService.ThroughputUpload:output_type -> mesh.v1.ThroughputResponse
+	13, // 39: mesh.v1.MeshService.ThroughputDownload:output_type -> mesh.v1.ThroughputChunk
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_v1_mesh_proto_init() }
@@ -453,7 +1345,7 @@ func file_v1_mesh_proto_init() {
 			}
 		}
 		file_v1_mesh_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
+			switch v := v.(*NodeStateUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -465,7 +1357,7 @@ func file_v1_mesh_proto_init() {
 			}
 		}
 		file_v1_mesh_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Samples); i {
+			switch v := v.(*PingReqRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -477,6 +1369,138 @@ func file_v1_mesh_proto_init() {
 			}
 		}
 		file_v1_mesh_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PingReqResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Samples); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushSamplesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PullStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tombstone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RttResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThroughputChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThroughputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThroughputResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_mesh_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Sample); i {
 			case 0:
 				return &v.state
@@ -494,13 +1518,14 @@ func file_v1_mesh_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_mesh_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      1,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_v1_mesh_proto_goTypes,
 		DependencyIndexes: file_v1_mesh_proto_depIdxs,
+		EnumInfos:         file_v1_mesh_proto_enumTypes,
 		MessageInfos:      file_v1_mesh_proto_msgTypes,
 	}.Build()
 	File_v1_mesh_proto = out.File
@@ -24,10 +24,16 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MeshServiceClient interface {
 	JoinMesh(ctx context.Context, in *Node, opts ...grpc.CallOption) (*JoinMeshResponse, error)
+	LeaveMesh(ctx context.Context, in *Node, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Ping(ctx context.Context, in *Node, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PingReq(ctx context.Context, in *PingReqRequest, opts ...grpc.CallOption) (*PingReqResponse, error)
 	NodeDiscovery(ctx context.Context, in *NodeDiscoveryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	PushSamples(ctx context.Context, in *Samples, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	Rtt(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	NodeStateUpdate(ctx context.Context, in *NodeStateUpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PushSamples(ctx context.Context, in *Samples, opts ...grpc.CallOption) (*PushSamplesResponse, error)
+	PullState(ctx context.Context, in *StateDigest, opts ...grpc.CallOption) (*PullStateResponse, error)
+	Rtt(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RttResponse, error)
+	ThroughputUpload(ctx context.Context, opts ...grpc.CallOption) (MeshService_ThroughputUploadClient, error)
+	ThroughputDownload(ctx context.Context, in *ThroughputRequest, opts ...grpc.CallOption) (MeshService_ThroughputDownloadClient, error)
 }
 
 type meshServiceClient struct {
@@ -47,6 +53,15 @@ func (c *meshServiceClient) JoinMesh(ctx context.Context, in *Node, opts ...grpc
 	return out, nil
 }
 
+func (c *meshServiceClient) LeaveMesh(ctx context.Context, in *Node, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/LeaveMesh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meshServiceClient) Ping(ctx context.Context, in *Node, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/Ping", in, out, opts...)
@@ -56,6 +71,15 @@ func (c *meshServiceClient) Ping(ctx context.Context, in *Node, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *meshServiceClient) PingReq(ctx context.Context, in *PingReqRequest, opts ...grpc.CallOption) (*PingReqResponse, error) {
+	out := new(PingReqResponse)
+	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/PingReq", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meshServiceClient) NodeDiscovery(ctx context.Context, in *NodeDiscoveryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/NodeDiscovery", in, out, opts...)
@@ -65,8 +89,17 @@ func (c *meshServiceClient) NodeDiscovery(ctx context.Context, in *NodeDiscovery
 	return out, nil
 }
 
-func (c *meshServiceClient) PushSamples(ctx context.Context, in *Samples, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *meshServiceClient) NodeStateUpdate(ctx context.Context, in *NodeStateUpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/NodeStateUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshServiceClient) PushSamples(ctx context.Context, in *Samples, opts ...grpc.CallOption) (*PushSamplesResponse, error) {
+	out := new(PushSamplesResponse)
 	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/PushSamples", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -74,8 +107,17 @@ func (c *meshServiceClient) PushSamples(ctx context.Context, in *Samples, opts .
 	return out, nil
 }
 
-func (c *meshServiceClient) Rtt(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *meshServiceClient) PullState(ctx context.Context, in *StateDigest, opts ...grpc.CallOption) (*PullStateResponse, error) {
+	out := new(PullStateResponse)
+	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/PullState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshServiceClient) Rtt(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RttResponse, error) {
+	out := new(RttResponse)
 	err := c.cc.Invoke(ctx, "/mesh.v1.MeshService/Rtt", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -83,15 +125,87 @@ func (c *meshServiceClient) Rtt(ctx context.Context, in *emptypb.Empty, opts ...
 	return out, nil
 }
 
+func (c *meshServiceClient) ThroughputUpload(ctx context.Context, opts ...grpc.CallOption) (MeshService_ThroughputUploadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MeshService_ServiceDesc.Streams[0], "/mesh.v1.MeshService/ThroughputUpload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &meshServiceThroughputUploadClient{stream}
+	return x, nil
+}
+
+type MeshService_ThroughputUploadClient interface {
+	Send(*ThroughputChunk) error
+	CloseAndRecv() (*ThroughputResponse, error)
+	grpc.ClientStream
+}
+
+type meshServiceThroughputUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *meshServiceThroughputUploadClient) Send(m *ThroughputChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *meshServiceThroughputUploadClient) CloseAndRecv() (*ThroughputResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ThroughputResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *meshServiceClient) ThroughputDownload(ctx context.Context, in *ThroughputRequest, opts ...grpc.CallOption) (MeshService_ThroughputDownloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MeshService_ServiceDesc.Streams[1], "/mesh.v1.MeshService/ThroughputDownload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &meshServiceThroughputDownloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MeshService_ThroughputDownloadClient interface {
+	Recv() (*ThroughputChunk, error)
+	grpc.ClientStream
+}
+
+type meshServiceThroughputDownloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *meshServiceThroughputDownloadClient) Recv() (*ThroughputChunk, error) {
+	m := new(ThroughputChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MeshServiceServer is the server API for MeshService service.
 // All implementations must embed UnimplementedMeshServiceServer
 // for forward compatibility
 type MeshServiceServer interface {
 	JoinMesh(context.Context, *Node) (*JoinMeshResponse, error)
+	LeaveMesh(context.Context, *Node) (*emptypb.Empty, error)
 	Ping(context.Context, *Node) (*emptypb.Empty, error)
+	PingReq(context.Context, *PingReqRequest) (*PingReqResponse, error)
 	NodeDiscovery(context.Context, *NodeDiscoveryRequest) (*emptypb.Empty, error)
-	PushSamples(context.Context, *Samples) (*emptypb.Empty, error)
-	Rtt(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	NodeStateUpdate(context.Context, *NodeStateUpdateRequest) (*emptypb.Empty, error)
+	PushSamples(context.Context, *Samples) (*PushSamplesResponse, error)
+	PullState(context.Context, *StateDigest) (*PullStateResponse, error)
+	Rtt(context.Context, *emptypb.Empty) (*RttResponse, error)
+	ThroughputUpload(MeshService_ThroughputUploadServer) error
+	ThroughputDownload(*ThroughputRequest, MeshService_ThroughputDownloadServer) error
 	mustEmbedUnimplementedMeshServiceServer()
 }
 
@@ -102,18 +216,36 @@ type UnimplementedMeshServiceServer struct {
 func (UnimplementedMeshServiceServer) JoinMesh(context.Context, *Node) (*JoinMeshResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JoinMesh not implemented")
 }
+func (UnimplementedMeshServiceServer) LeaveMesh(context.Context, *Node) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveMesh not implemented")
+}
 func (UnimplementedMeshServiceServer) Ping(context.Context, *Node) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedMeshServiceServer) PingReq(context.Context, *PingReqRequest) (*PingReqResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PingReq not implemented")
+}
 func (UnimplementedMeshServiceServer) NodeDiscovery(context.Context, *NodeDiscoveryRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NodeDiscovery not implemented")
 }
-func (UnimplementedMeshServiceServer) PushSamples(context.Context, *Samples) (*emptypb.Empty, error) {
+func (UnimplementedMeshServiceServer) NodeStateUpdate(context.Context, *NodeStateUpdateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeStateUpdate not implemented")
+}
+func (UnimplementedMeshServiceServer) PushSamples(context.Context, *Samples) (*PushSamplesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PushSamples not implemented")
 }
-func (UnimplementedMeshServiceServer) Rtt(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+func (UnimplementedMeshServiceServer) PullState(context.Context, *StateDigest) (*PullStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullState not implemented")
+}
+func (UnimplementedMeshServiceServer) Rtt(context.Context, *emptypb.Empty) (*RttResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rtt not implemented")
 }
+func (UnimplementedMeshServiceServer) ThroughputUpload(MeshService_ThroughputUploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method ThroughputUpload not implemented")
+}
+func (UnimplementedMeshServiceServer) ThroughputDownload(*ThroughputRequest, MeshService_ThroughputDownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method ThroughputDownload not implemented")
+}
 func (UnimplementedMeshServiceServer) mustEmbedUnimplementedMeshServiceServer() {}
 
 // UnsafeMeshServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -145,6 +277,24 @@ func _MeshService_JoinMesh_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_LeaveMesh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Node)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).LeaveMesh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mesh.v1.MeshService/LeaveMesh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).LeaveMesh(ctx, req.(*Node))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Node)
 	if err := dec(in); err != nil {
@@ -163,6 +313,24 @@ func _MeshService_Ping_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_PingReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingReqRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).PingReq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mesh.v1.MeshService/PingReq",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).PingReq(ctx, req.(*PingReqRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshService_NodeDiscovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NodeDiscoveryRequest)
 	if err := dec(in); err != nil {
@@ -181,6 +349,24 @@ func _MeshService_NodeDiscovery_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_NodeStateUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeStateUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).NodeStateUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mesh.v1.MeshService/NodeStateUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).NodeStateUpdate(ctx, req.(*NodeStateUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshService_PushSamples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Samples)
 	if err := dec(in); err != nil {
@@ -199,6 +385,24 @@ func _MeshService_PushSamples_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_PullState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateDigest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).PullState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mesh.v1.MeshService/PullState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).PullState(ctx, req.(*StateDigest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshService_Rtt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -217,6 +421,53 @@ func _MeshService_Rtt_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_ThroughputUpload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MeshServiceServer).ThroughputUpload(&meshServiceThroughputUploadServer{stream})
+}
+
+type MeshService_ThroughputUploadServer interface {
+	SendAndClose(*ThroughputResponse) error
+	Recv() (*ThroughputChunk, error)
+	grpc.ServerStream
+}
+
+type meshServiceThroughputUploadServer struct {
+	grpc.ServerStream
+}
+
+func (x *meshServiceThroughputUploadServer) SendAndClose(m *ThroughputResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *meshServiceThroughputUploadServer) Recv() (*ThroughputChunk, error) {
+	m := new(ThroughputChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _MeshService_ThroughputDownload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ThroughputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MeshServiceServer).ThroughputDownload(m, &meshServiceThroughputDownloadServer{stream})
+}
+
+type MeshService_ThroughputDownloadServer interface {
+	Send(*ThroughputChunk) error
+	grpc.ServerStream
+}
+
+type meshServiceThroughputDownloadServer struct {
+	grpc.ServerStream
+}
+
+func (x *meshServiceThroughputDownloadServer) Send(m *ThroughputChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // MeshService_ServiceDesc is the grpc.ServiceDesc for MeshService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -228,23 +479,50 @@ var MeshService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "JoinMesh",
 			Handler:    _MeshService_JoinMesh_Handler,
 		},
+		{
+			MethodName: "LeaveMesh",
+			Handler:    _MeshService_LeaveMesh_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _MeshService_Ping_Handler,
 		},
+		{
+			MethodName: "PingReq",
+			Handler:    _MeshService_PingReq_Handler,
+		},
 		{
 			MethodName: "NodeDiscovery",
 			Handler:    _MeshService_NodeDiscovery_Handler,
 		},
+		{
+			MethodName: "NodeStateUpdate",
+			Handler:    _MeshService_NodeStateUpdate_Handler,
+		},
 		{
 			MethodName: "PushSamples",
 			Handler:    _MeshService_PushSamples_Handler,
 		},
+		{
+			MethodName: "PullState",
+			Handler:    _MeshService_PullState_Handler,
+		},
 		{
 			MethodName: "Rtt",
 			Handler:    _MeshService_Rtt_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ThroughputUpload",
+			Handler:       _MeshService_ThroughputUpload_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ThroughputDownload",
+			Handler:       _MeshService_ThroughputDownload_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "v1/mesh.proto",
 }